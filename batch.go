@@ -0,0 +1,56 @@
+package rest
+
+import (
+	"context"
+	"net/http"
+)
+
+// BatchResult is the result of a item of a batch, like a resource created by a bulk create
+type BatchResult struct {
+	// Status of item, 200 when is 0 and there is no error
+	Status int
+	// Header is sent on headers field of item, like the Location of resource created
+	Header http.Header
+	// Body is the value sent on body field of item
+	Body interface{}
+	// Err is sent on body field like Error, with the status resolved by DefaultErrorMapper
+	Err error
+}
+
+// batchDocument is the json sent by Batch
+type batchDocument struct {
+	Results []batchItem `json:"results"`
+}
+
+type batchItem struct {
+	Status  int         `json:"status"`
+	Headers http.Header `json:"headers,omitempty"`
+	Body    interface{} `json:"body,omitempty"`
+}
+
+// Batch respond 207 with the status, headers and body of each result, on order, like
+// {"results":[{"status":201,"headers":{"Location":["/products/1"]},"body":{...}},{"status":422,"body":{"message":...}}]}
+func Batch(w http.ResponseWriter, results []BatchResult, opts ...Option) (int, error) {
+
+	ctx := context.Background()
+
+	document := &batchDocument{Results: make([]batchItem, len(results))}
+
+	for i, result := range results {
+
+		item := batchItem{Status: result.Status, Headers: result.Header, Body: result.Body}
+
+		if result.Err != nil {
+			item.Status = DefaultErrorMapper.resolve(result.Err, result.Status)
+			item.Body = errorBody(ctx, applicationJson, result.Err, item.Status)
+		}
+
+		if item.Status == 0 {
+			item.Status = http.StatusOK
+		}
+
+		document.Results[i] = item
+	}
+
+	return newCall(ctx, opts...).marshalled(w, document, http.StatusMultiStatus)
+}
@@ -0,0 +1,43 @@
+package rest_test
+
+import (
+	"errors"
+	"github.com/edermanoel94/rest-go"
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestBatch(t *testing.T) {
+
+	testCases := []struct {
+		description string
+		results     []rest.BatchResult
+		body        string
+	}{
+		{"should send status, headers and body of each result", []rest.BatchResult{
+			{Status: http.StatusCreated, Header: http.Header{"Location": {"/products/1"}}, Body: map[string]int{"id": 1}},
+			{Status: http.StatusConflict, Err: errors.New("product already exists")},
+		}, `{"results":[{"status":201,"headers":{"Location":["/products/1"]},"body":{"id":1}},{"status":409,"body":{"message":"product already exists"}}]}`},
+		{"should send status of error", []rest.BatchResult{
+			{Err: &rest.ValidationError{Fields: []rest.FieldError{{Name: "name", Rule: "required", Message: "name is required"}}}},
+		}, `{"results":[{"status":422,"body":{"message":"validation failed: name is required","fields":[{"name":"name","rule":"required","message":"name is required"}]}}]}`},
+		{"should send ok when status is not set", []rest.BatchResult{{}}, `{"results":[{"status":200}]}`},
+		{"should send empty results", []rest.BatchResult{}, `{"results":[]}`},
+	}
+
+	for _, tc := range testCases {
+
+		t.Run(tc.description, func(t *testing.T) {
+
+			recorder := httptest.NewRecorder()
+
+			rest.Batch(recorder, tc.results)
+
+			assert.Equal(t, http.StatusMultiStatus, recorder.Code)
+			assert.Equal(t, "application/json", recorder.Header().Get("Content-Type"))
+			assert.Equal(t, tc.body, recorder.Body.String())
+		})
+	}
+}
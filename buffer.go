@@ -0,0 +1,32 @@
+package rest
+
+import (
+	"bytes"
+	"sync"
+)
+
+// maxPooledBuffer is the capacity limit of buffers returned to pool, so a big body don't stay on memory
+const maxPooledBuffer = 64 << 10
+
+var bufferPool = sync.Pool{
+	New: func() interface{} {
+		return &bytes.Buffer{}
+	},
+}
+
+func getBuffer() *bytes.Buffer {
+	return bufferPool.Get().(*bytes.Buffer)
+}
+
+func putBuffer(buf *bytes.Buffer) {
+	if buf.Cap() > maxPooledBuffer {
+		return
+	}
+	buf.Reset()
+	bufferPool.Put(buf)
+}
+
+// BufferEncoder can be implemented by a Encoder to write on a pooled buffer, avoiding a allocation per response
+type BufferEncoder interface {
+	EncodeTo(buf *bytes.Buffer, v interface{}) error
+}
@@ -0,0 +1,120 @@
+package rest
+
+import (
+	"bytes"
+	"encoding/json"
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"testing"
+)
+
+type discardWriter struct {
+	header http.Header
+}
+
+func (d *discardWriter) Header() http.Header {
+	return d.header
+}
+
+func (d *discardWriter) Write(b []byte) (int, error) {
+	return len(b), nil
+}
+
+func (d *discardWriter) WriteHeader(int) {}
+
+type benchProduct struct {
+	ID    int      `json:"id"`
+	Name  string   `json:"name"`
+	Price float64  `json:"price"`
+	Tags  []string `json:"tags"`
+}
+
+func TestJsonEncoder(t *testing.T) {
+
+	t.Run("should encode like json.Marshal", func(t *testing.T) {
+
+		product := benchProduct{ID: 1, Name: "<tv>", Price: 10.5, Tags: []string{"a"}}
+
+		expected, _ := json.Marshal(product)
+
+		buf := &bytes.Buffer{}
+
+		err := jsonEncoder{}.EncodeTo(buf, product)
+
+		assert.NoError(t, err)
+		assert.Equal(t, string(expected), buf.String())
+	})
+
+	t.Run("should not write on buffer when fail", func(t *testing.T) {
+
+		buf := &bytes.Buffer{}
+
+		err := jsonEncoder{}.EncodeTo(buf, make(chan int))
+
+		assert.Error(t, err)
+		assert.Equal(t, 0, buf.Len())
+	})
+}
+
+func TestPutBuffer(t *testing.T) {
+
+	t.Run("should reset buffer returned to pool", func(t *testing.T) {
+
+		buf := getBuffer()
+		buf.WriteString("body")
+
+		putBuffer(buf)
+
+		assert.Equal(t, 0, buf.Len())
+	})
+
+	t.Run("should not keep large buffers", func(t *testing.T) {
+
+		buf := getBuffer()
+		buf.Grow(maxPooledBuffer * 2)
+		buf.WriteString("body")
+
+		putBuffer(buf)
+
+		assert.Equal(t, "body", buf.String())
+	})
+}
+
+func BenchmarkMarshalled(b *testing.B) {
+
+	w := &discardWriter{header: http.Header{}}
+
+	product := benchProduct{ID: 1, Name: "tv", Price: 10.5, Tags: []string{"electronics", "home"}}
+
+	b.ReportAllocs()
+
+	for i := 0; i < b.N; i++ {
+		Marshalled(w, product, http.StatusOK)
+	}
+}
+
+func BenchmarkResponse(b *testing.B) {
+
+	w := &discardWriter{header: http.Header{}}
+
+	body := []byte(`{"id":1,"name":"tv","price":10.5,"tags":["electronics","home"]}`)
+
+	b.ReportAllocs()
+
+	for i := 0; i < b.N; i++ {
+		Response(w, body, http.StatusOK)
+	}
+}
+
+func BenchmarkError(b *testing.B) {
+
+	w := &discardWriter{header: http.Header{}}
+
+	err := &BindError{Status: http.StatusBadRequest, Message: "invalid product"}
+
+	b.ReportAllocs()
+
+	for i := 0; i < b.N; i++ {
+		Error(w, err, http.StatusBadRequest)
+	}
+}
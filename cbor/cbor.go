@@ -0,0 +1,29 @@
+// Package cbor register a application/cbor encoder on rest, importing it is enough
+// to negotiate cbor, and CBOR send it explicitly. Struct fields use the json tags.
+package cbor
+
+import (
+	"github.com/edermanoel94/rest-go"
+	"github.com/fxamacker/cbor/v2"
+	"net/http"
+)
+
+// MediaType is the content type of cbor responses
+const MediaType = "application/cbor"
+
+func init() {
+	rest.RegisterEncoder(MediaType, Encoder{})
+}
+
+// Encoder marshal values to cbor, the cbor tag is used and json tag when missing
+type Encoder struct{}
+
+// Encode marshal v to cbor
+func (Encoder) Encode(v interface{}) ([]byte, error) {
+	return cbor.Marshal(v)
+}
+
+// CBOR marshall v and respond application/cbor, errors are sent on cbor too
+func CBOR(w http.ResponseWriter, v interface{}, code int, opts ...rest.Option) (int, error) {
+	return rest.Encoded(w, MediaType, v, code, opts...)
+}
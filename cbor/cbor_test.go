@@ -0,0 +1,61 @@
+package cbor_test
+
+import (
+	"github.com/edermanoel94/rest-go"
+	"github.com/edermanoel94/rest-go/cbor"
+	decoder "github.com/fxamacker/cbor/v2"
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+type product struct {
+	ID   int    `json:"id"`
+	Name string `json:"name"`
+}
+
+func TestCBOR(t *testing.T) {
+
+	t.Run("should marshal to cbor with json tags", func(t *testing.T) {
+
+		recorder := httptest.NewRecorder()
+
+		cbor.CBOR(recorder, product{ID: 1, Name: "tv"}, http.StatusOK)
+
+		var decoded product
+
+		err := decoder.Unmarshal(recorder.Body.Bytes(), &decoded)
+
+		assert.NoError(t, err)
+		assert.Equal(t, "application/cbor", recorder.Header().Get("Content-Type"))
+		assert.Equal(t, product{ID: 1, Name: "tv"}, decoded)
+	})
+
+	t.Run("should negotiate cbor", func(t *testing.T) {
+
+		request := httptest.NewRequest(http.MethodGet, "/products", nil)
+		request.Header.Set("Accept", "application/cbor")
+
+		recorder := httptest.NewRecorder()
+
+		rest.Negotiate(recorder, request, []product{{ID: 1}}, http.StatusOK)
+
+		assert.Equal(t, "application/cbor", recorder.Header().Get("Content-Type"))
+	})
+
+	t.Run("should send error on cbor", func(t *testing.T) {
+
+		recorder := httptest.NewRecorder()
+
+		cbor.CBOR(recorder, make(chan int), http.StatusOK)
+
+		var decoded map[string]interface{}
+
+		err := decoder.Unmarshal(recorder.Body.Bytes(), &decoded)
+
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusInternalServerError, recorder.Code)
+		assert.Contains(t, decoded["message"], "chan int")
+	})
+}
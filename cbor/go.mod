@@ -0,0 +1,18 @@
+module github.com/edermanoel94/rest-go/cbor
+
+go 1.20
+
+replace github.com/edermanoel94/rest-go => ../
+
+require (
+	github.com/edermanoel94/rest-go v0.0.0-00010101000000-000000000000
+	github.com/fxamacker/cbor/v2 v2.9.4
+	github.com/stretchr/testify v1.5.0
+)
+
+require (
+	github.com/davecgh/go-spew v1.1.0 // indirect
+	github.com/pmezard/go-difflib v1.0.0 // indirect
+	github.com/x448/float16 v0.8.4 // indirect
+	gopkg.in/yaml.v2 v2.2.2 // indirect
+)
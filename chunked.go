@@ -0,0 +1,84 @@
+package rest
+
+import (
+	"errors"
+	"net/http"
+)
+
+var (
+	ErrChunkedClosed = errors.New("chunked response is closed")
+)
+
+// Chunker write a long running operation as json lines (application/x-ndjson), like {"progress":42},
+// ending with {"result":...} or {"error":{"message":...}}
+type Chunker struct {
+	*Streamer
+}
+
+type progressChunk struct {
+	Progress int `json:"progress"`
+}
+
+type resultChunk struct {
+	Result interface{} `json:"result"`
+}
+
+type errorChunk struct {
+	Error interface{} `json:"error"`
+}
+
+// Chunked start a chunked response, the status 200 is sent on first chunk. Proxies are asked to not
+// buffer the chunks, and when the writer can't flush the chunks are sent when the handler return.
+func Chunked(w http.ResponseWriter) *Chunker {
+
+	s := newStreamer(w, ErrChunkedClosed)
+
+	s.header = http.Header{"Cache-Control": {"no-cache"}, "X-Accel-Buffering": {"no"}}
+
+	return &Chunker{Streamer: s}
+}
+
+// Flushable report if the chunks reach the client when flushed
+func (c *Chunker) Flushable() bool {
+	return c.flusher != nil
+}
+
+// Progress send the progress of operation, like {"progress":42}
+func (c *Chunker) Progress(percent int) error {
+	return c.Send(&progressChunk{Progress: percent})
+}
+
+// Flush send the chunks written to the client, it does nothing when the writer can't flush
+func (c *Chunker) Flush() {
+	if c.flusher != nil {
+		c.start()
+		c.flusher.Flush()
+	}
+}
+
+// Result send the final result, like {"result":{"url":"/reports/1"}}, and close the response
+func (c *Chunker) Result(v interface{}) error {
+
+	if err := c.Send(&resultChunk{Result: v}); err != nil {
+		return err
+	}
+
+	return c.Close()
+}
+
+// Fail send the error of operation, like {"error":{"message":"..."}}, and close the response.
+// When nothing was sent yet, the status of error is sent like Error, otherwise the status is 200.
+func (c *Chunker) Fail(err error) error {
+
+	if !c.started {
+		c.status = DefaultErrorMapper.resolve(err, 0)
+	}
+
+	c.call.record(err)
+
+	if sendErr := c.Send(&errorChunk{Error: errorBody(c.call.ctx, applicationJson, err, c.status)}); sendErr != nil {
+		return sendErr
+	}
+
+	return c.Close()
+}
@@ -0,0 +1,102 @@
+package rest_test
+
+import (
+	"github.com/edermanoel94/rest-go"
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestChunked(t *testing.T) {
+
+	t.Run("should send progress and result, flushing each chunk", func(t *testing.T) {
+
+		recorder := &flushCounter{ResponseRecorder: httptest.NewRecorder()}
+
+		chunked := rest.Chunked(recorder)
+
+		assert.True(t, chunked.Flushable())
+		assert.NoError(t, chunked.Progress(42))
+		assert.NoError(t, chunked.Progress(100))
+		assert.NoError(t, chunked.Result(map[string]string{"url": "/reports/1"}))
+
+		assert.Equal(t, http.StatusOK, recorder.Code)
+		assert.Equal(t, "application/x-ndjson", recorder.Header().Get("Content-Type"))
+		assert.Equal(t, "no", recorder.Header().Get("X-Accel-Buffering"))
+		assert.Equal(t, "{\"progress\":42}\n{\"progress\":100}\n{\"result\":{\"url\":\"/reports/1\"}}\n", recorder.Body.String())
+		assert.Equal(t, 3, recorder.flushes)
+		assert.Equal(t, rest.ErrChunkedClosed, chunked.Progress(1))
+	})
+
+	t.Run("should send chunks when writer can't flush", func(t *testing.T) {
+
+		recorder := httptest.NewRecorder()
+
+		chunked := rest.Chunked(plainWriter{recorder})
+
+		assert.False(t, chunked.Flushable())
+		assert.NoError(t, chunked.Progress(50))
+		chunked.Flush()
+		assert.NoError(t, chunked.Result(true))
+
+		assert.Equal(t, http.StatusOK, recorder.Code)
+		assert.Equal(t, "{\"progress\":50}\n{\"result\":true}\n", recorder.Body.String())
+	})
+
+	testCases := []struct {
+		description string
+		progress    bool
+		statusCode  int
+		body        string
+	}{
+		{"should send status of error when nothing was sent", false,
+			http.StatusForbidden, "{\"error\":{\"message\":\"report not allowed\"}}\n"},
+		{"should send error after progress", true,
+			http.StatusOK, "{\"progress\":10}\n{\"error\":{\"message\":\"report not allowed\"}}\n"},
+	}
+
+	for _, tc := range testCases {
+
+		t.Run(tc.description, func(t *testing.T) {
+
+			recorder := httptest.NewRecorder()
+
+			chunked := rest.Chunked(recorder)
+
+			if tc.progress {
+				assert.NoError(t, chunked.Progress(10))
+			}
+
+			assert.NoError(t, chunked.Fail(&forbiddenError{"report not allowed"}))
+
+			assert.Equal(t, tc.statusCode, recorder.Code)
+			assert.Equal(t, tc.body, recorder.Body.String())
+		})
+	}
+
+	t.Run("should send status mapped to error when nothing was sent", func(t *testing.T) {
+
+		rest.MapError(errHandlerMissing, http.StatusNotFound)
+
+		recorder := httptest.NewRecorder()
+
+		assert.NoError(t, rest.Chunked(recorder).Fail(errHandlerMissing))
+
+		assert.Equal(t, http.StatusNotFound, recorder.Code)
+	})
+
+	t.Run("should report the error of writer", func(t *testing.T) {
+
+		chunked := rest.Chunked(brokenWriter{httptest.NewRecorder()})
+
+		assert.EqualError(t, chunked.Progress(1), "broken pipe")
+		assert.EqualError(t, chunked.Result(2), "broken pipe")
+		assert.EqualError(t, chunked.Close(), "broken pipe")
+	})
+}
+
+// plainWriter hide the http.Flusher of writer
+type plainWriter struct {
+	http.ResponseWriter
+}
@@ -0,0 +1,121 @@
+package rest
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"strings"
+)
+
+// maxErrorBody is the size limit of a error body read by DecodeError
+const maxErrorBody = 1 << 20
+
+// ResponseError is a error response of a service built with the package, returned by Decode and DecodeError
+type ResponseError struct {
+	Status    int
+	Message   string
+	Code      string
+	RequestID string
+	Fields    []FieldError
+	Errors    []*ResponseError
+	// Body is the body of response, when it isn't a error of the package
+	Body []byte
+}
+
+func (r *ResponseError) Error() string {
+	return fmt.Sprintf("%d %s: %s", r.Status, http.StatusText(r.Status), r.Message)
+}
+
+// StatusCode return the status of response, so Error respond the same status
+func (r *ResponseError) StatusCode() int {
+	return r.Status
+}
+
+type responseErrorBody struct {
+	Message   string              `json:"message"`
+	Code      string              `json:"code"`
+	RequestID string              `json:"request_id"`
+	Fields    []FieldError        `json:"fields"`
+	Errors    []responseErrorBody `json:"errors"`
+}
+
+func (b responseErrorBody) toError(status int) *ResponseError {
+
+	err := &ResponseError{Status: status, Message: b.Message, Code: b.Code, RequestID: b.RequestID, Fields: b.Fields}
+
+	for _, item := range b.Errors {
+		err.Errors = append(err.Errors, item.toError(status))
+	}
+
+	return err
+}
+
+// Decode unmarshal the json body of response on v and close it, a status which is not 2xx return
+// the error of DecodeError, and a body which is not json return a error. A nil v discard the body.
+func Decode(resp *http.Response, v interface{}) error {
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return DecodeError(resp)
+	}
+
+	defer resp.Body.Close()
+
+	if v == nil || resp.StatusCode == http.StatusNoContent {
+		io.Copy(ioutil.Discard, resp.Body)
+		return nil
+	}
+
+	if !isJsonMediaType(resp.Header.Get(contentType)) {
+		return fmt.Errorf("couldn't decode response, content type %q is not json", resp.Header.Get(contentType))
+	}
+
+	body, err := ioutil.ReadAll(resp.Body)
+
+	if err != nil {
+		return fmt.Errorf("couldn't read body of response: %w", err)
+	}
+
+	if err := unmarshalJson(body, v); err != nil {
+		return fmt.Errorf("couldn't unmarshal: %w", err)
+	}
+
+	return nil
+}
+
+// DecodeError read the error sent by Error on response, returning a *ResponseError, and close the body.
+// When the body is not a json error, the message is the body or the status text.
+func DecodeError(resp *http.Response) error {
+
+	defer resp.Body.Close()
+
+	body, err := ioutil.ReadAll(io.LimitReader(resp.Body, maxErrorBody))
+
+	if err != nil {
+		return fmt.Errorf("couldn't read body of response: %w", err)
+	}
+
+	if isJsonMediaType(resp.Header.Get(contentType)) {
+
+		var decoded responseErrorBody
+
+		if err := unmarshalJson(body, &decoded); err == nil && decoded.Message != "" {
+			return decoded.toError(resp.StatusCode)
+		}
+	}
+
+	message := strings.TrimSpace(string(body))
+
+	if message == "" {
+		message = http.StatusText(resp.StatusCode)
+	}
+
+	return &ResponseError{Status: resp.StatusCode, Message: message, Body: body}
+}
+
+// IsStatus report if err is a *ResponseError with status
+func IsStatus(err error, status int) bool {
+	var respErr *ResponseError
+	return errors.As(err, &respErr) && respErr.Status == status
+}
@@ -0,0 +1,118 @@
+package rest_test
+
+import (
+	"errors"
+	"github.com/edermanoel94/rest-go"
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestDecode(t *testing.T) {
+
+	type product struct {
+		Name string `json:"name"`
+	}
+
+	t.Run("should decode the body of response", func(t *testing.T) {
+
+		recorder := httptest.NewRecorder()
+
+		rest.Marshalled(recorder, &product{Name: "tv"}, http.StatusOK)
+
+		var result product
+
+		err := rest.Decode(recorder.Result(), &result)
+
+		assert.NoError(t, err)
+		assert.Equal(t, "tv", result.Name)
+	})
+
+	t.Run("should accept a response without content", func(t *testing.T) {
+
+		recorder := httptest.NewRecorder()
+
+		rest.NoContent(recorder)
+
+		var result product
+
+		assert.NoError(t, rest.Decode(recorder.Result(), &result))
+	})
+
+	t.Run("should fail when body is not json", func(t *testing.T) {
+
+		recorder := httptest.NewRecorder()
+		recorder.Header().Set("Content-Type", "text/html")
+		recorder.WriteString("<html></html>")
+
+		var result product
+
+		err := rest.Decode(recorder.Result(), &result)
+
+		assert.EqualError(t, err, `couldn't decode response, content type "text/html" is not json`)
+	})
+
+	t.Run("should return the error of response", func(t *testing.T) {
+
+		recorder := httptest.NewRecorder()
+
+		rest.Error(recorder, rest.Errors{&rest.ValidationError{Fields: []rest.FieldError{{Name: "name", Rule: "required", Message: "name is required"}}}}, http.StatusUnprocessableEntity)
+
+		var result product
+
+		err := rest.Decode(recorder.Result(), &result)
+
+		var respErr *rest.ResponseError
+
+		assert.True(t, errors.As(err, &respErr))
+		assert.Equal(t, http.StatusUnprocessableEntity, respErr.StatusCode())
+		assert.Equal(t, "validation failed: name is required", respErr.Message)
+		assert.Equal(t, []rest.FieldError{{Name: "name", Rule: "required", Message: "name is required"}}, respErr.Errors[0].Fields)
+		assert.True(t, rest.IsStatus(err, http.StatusUnprocessableEntity))
+	})
+}
+
+func TestDecodeError(t *testing.T) {
+
+	testCases := []struct {
+		description string
+		contentType string
+		body        string
+		expected    *rest.ResponseError
+	}{
+		{"should decode a error of package", "application/json", `{"message":"order 1 not found","code":"order_not_found","request_id":"abc"}`,
+			&rest.ResponseError{Status: http.StatusNotFound, Message: "order 1 not found", Code: "order_not_found", RequestID: "abc"}},
+		{"should use body when is not a error of package", "text/plain", "404 page not found\n",
+			&rest.ResponseError{Status: http.StatusNotFound, Message: "404 page not found", Body: []byte("404 page not found\n")}},
+		{"should use status text when body is empty", "", "",
+			&rest.ResponseError{Status: http.StatusNotFound, Message: "Not Found", Body: []byte{}}},
+	}
+
+	for _, tc := range testCases {
+
+		t.Run(tc.description, func(t *testing.T) {
+
+			recorder := httptest.NewRecorder()
+			recorder.Header().Set("Content-Type", tc.contentType)
+			recorder.WriteHeader(http.StatusNotFound)
+			recorder.WriteString(tc.body)
+
+			err := rest.DecodeError(recorder.Result())
+
+			assert.Equal(t, tc.expected, err)
+		})
+	}
+
+	t.Run("should send the status of response on Error", func(t *testing.T) {
+
+		err := &rest.ResponseError{Status: http.StatusConflict, Message: "already exists"}
+
+		recorder := httptest.NewRecorder()
+
+		rest.Error(recorder, err, http.StatusInternalServerError)
+
+		assert.Equal(t, http.StatusConflict, recorder.Code)
+		assert.Equal(t, `{"message":"409 Conflict: already exists"}`, recorder.Body.String())
+	})
+}
@@ -0,0 +1,316 @@
+package rest
+
+import (
+	"compress/flate"
+	"compress/gzip"
+	"io"
+	"mime"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// Compressor create a writer which compress to w, if it has a Flush method it is called
+// when the handler flush the response
+type Compressor func(w io.Writer) io.WriteCloser
+
+// CompressOptions configure the Compress middleware
+type CompressOptions struct {
+	// MinSize is the size of body which start the compression, smaller bodies are sent as is
+	MinSize int
+	// ContentTypes are the media types compressed, a type ending with /* match the subtypes
+	ContentTypes []string
+}
+
+// DefaultCompressOptions is used by Compress
+var DefaultCompressOptions = CompressOptions{
+	MinSize: 1024,
+	ContentTypes: []string{
+		applicationJson,
+		applicationXml,
+		applicationNdjson,
+		textEventStream,
+		"text/*",
+	},
+}
+
+type registeredCompressor struct {
+	encoding   string
+	compressor Compressor
+}
+
+var (
+	compressorsMu sync.RWMutex
+	// compressors on order of preference of server
+	compressors = []registeredCompressor{
+		{encoding: "gzip", compressor: func(w io.Writer) io.WriteCloser {
+			return gzip.NewWriter(w)
+		}},
+		{encoding: "deflate", compressor: func(w io.Writer) io.WriteCloser {
+			writer, _ := flate.NewWriter(w, flate.DefaultCompression)
+			return writer
+		}},
+	}
+)
+
+// RegisterCompressor add a compressor for a content encoding, like br, which become the
+// preferred encoding of server when the client accept it with same quality of others
+func RegisterCompressor(encoding string, compressor Compressor) {
+
+	compressorsMu.Lock()
+	defer compressorsMu.Unlock()
+
+	for i, registered := range compressors {
+		if registered.encoding == encoding {
+			compressors = append(compressors[:i:i], compressors[i+1:]...)
+			break
+		}
+	}
+
+	compressors = append([]registeredCompressor{{encoding: encoding, compressor: compressor}}, compressors...)
+}
+
+// Compress is a middleware which compress the bodies larger than DefaultCompressOptions.MinSize
+// with the encoding negotiated by Accept-Encoding
+func Compress(next http.Handler) http.Handler {
+	return CompressWith(DefaultCompressOptions)(next)
+}
+
+// CompressWith create a middleware like Compress with the options given
+func CompressWith(opts CompressOptions) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+
+			if r.Method == http.MethodHead {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			encoding, compressor := negotiateEncoding(r.Header.Get("Accept-Encoding"))
+
+			if compressor == nil {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			cw := &compressWriter{
+				responseWriter: newResponseWriter(w),
+				opts:           &opts,
+				encoding:       encoding,
+				newCompressor:  compressor,
+				status:         http.StatusOK,
+			}
+
+			defer cw.close()
+
+			next.ServeHTTP(expose(cw, w), r)
+		})
+	}
+}
+
+// negotiateEncoding choose the compressor for Accept-Encoding header
+func negotiateEncoding(header string) (string, Compressor) {
+
+	qualities := make(map[string]float64)
+
+	for _, part := range strings.Split(header, ",") {
+
+		encoding, quality := strings.TrimSpace(part), 1.0
+
+		if i := strings.Index(encoding, ";"); i != -1 {
+			if q := strings.TrimSpace(encoding[i+1:]); strings.HasPrefix(q, "q=") {
+				if value, err := strconv.ParseFloat(q[2:], 64); err == nil {
+					quality = value
+				}
+			}
+			encoding = strings.TrimSpace(encoding[:i])
+		}
+
+		if encoding != "" {
+			qualities[strings.ToLower(encoding)] = quality
+		}
+	}
+
+	compressorsMu.RLock()
+	defer compressorsMu.RUnlock()
+
+	best, bestQuality := registeredCompressor{}, 0.0
+
+	for _, registered := range compressors {
+
+		quality, ok := qualities[registered.encoding]
+
+		if !ok {
+			quality = qualities["*"]
+		}
+
+		if quality > bestQuality {
+			best, bestQuality = registered, quality
+		}
+	}
+
+	return best.encoding, best.compressor
+}
+
+// compressWriter buffer the body until MinSize to decide if compress it
+type compressWriter struct {
+	*responseWriter
+	opts          *CompressOptions
+	encoding      string
+	newCompressor Compressor
+	compressor    io.WriteCloser
+	status        int
+	headerPending bool
+	decided       bool
+	buf           []byte
+}
+
+func (cw *compressWriter) WriteHeader(code int) {
+
+	if cw.decided || cw.headerPending {
+		return
+	}
+
+	// informational responses are sent as is
+	if code >= 100 && code < 200 {
+		cw.responseWriter.ResponseWriter.WriteHeader(code)
+		return
+	}
+
+	cw.status = code
+	cw.headerPending = true
+
+	if !bodyAllowed(code) {
+		cw.decide(false)
+	}
+}
+
+func (cw *compressWriter) Write(b []byte) (int, error) {
+
+	if !cw.headerPending && !cw.decided {
+		cw.WriteHeader(http.StatusOK)
+	}
+
+	if cw.decided {
+		if cw.compressor != nil {
+			return cw.compressor.Write(b)
+		}
+		return cw.responseWriter.Write(b)
+	}
+
+	cw.buf = append(cw.buf, b...)
+
+	if len(cw.buf) >= cw.opts.MinSize {
+		if err := cw.decide(true); err != nil {
+			return 0, err
+		}
+	}
+
+	return len(b), nil
+}
+
+// Flush send the buffered body, compressing it when is large enough
+func (cw *compressWriter) Flush() {
+
+	if !cw.decided {
+		if !cw.headerPending {
+			cw.WriteHeader(http.StatusOK)
+		}
+		cw.decide(len(cw.buf) >= cw.opts.MinSize)
+	}
+
+	if flusher, ok := cw.compressor.(interface{ Flush() error }); ok {
+		flusher.Flush()
+	}
+
+	cw.responseWriter.Flush()
+}
+
+// ReadFrom copy through Write, so the body is compressed
+func (cw *compressWriter) ReadFrom(r io.Reader) (int64, error) {
+	return io.Copy(writerOnly{cw}, r)
+}
+
+// decide send the header, starting the compression when enough is true and the content can be compressed
+func (cw *compressWriter) decide(enough bool) error {
+
+	cw.decided = true
+
+	header := cw.Header()
+
+	if cw.compressible() {
+
+		header.Add("Vary", "Accept-Encoding")
+
+		if enough {
+			header.Set("Content-Encoding", cw.encoding)
+			header.Del("Content-Length")
+			cw.compressor = cw.newCompressor(cw.responseWriter)
+		}
+	}
+
+	cw.responseWriter.WriteHeader(cw.status)
+
+	if len(cw.buf) == 0 {
+		return nil
+	}
+
+	buf := cw.buf
+	cw.buf = nil
+
+	if cw.compressor != nil {
+		_, err := cw.compressor.Write(buf)
+		return err
+	}
+
+	_, err := cw.responseWriter.Write(buf)
+
+	return err
+}
+
+func (cw *compressWriter) compressible() bool {
+
+	if !bodyAllowed(cw.status) || cw.status == http.StatusPartialContent {
+		return false
+	}
+
+	header := cw.Header()
+
+	if header.Get("Content-Encoding") != "" {
+		return false
+	}
+
+	mediaType, _, err := mime.ParseMediaType(header.Get(contentType))
+
+	if err != nil {
+		return false
+	}
+
+	for _, allowed := range cw.opts.ContentTypes {
+		if allowed == mediaType || strings.HasSuffix(allowed, "/*") && strings.HasPrefix(mediaType, strings.TrimSuffix(allowed, "*")) {
+			return true
+		}
+		if allowed == applicationJson && isJsonMediaType(mediaType) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// close send what is buffered and finish the compression
+func (cw *compressWriter) close() {
+
+	if !cw.decided && cw.headerPending {
+		cw.decide(false)
+	}
+
+	if cw.compressor != nil {
+		cw.compressor.Close()
+	}
+}
+
+func bodyAllowed(code int) bool {
+	return code >= 200 && code != http.StatusNoContent && code != http.StatusNotModified
+}
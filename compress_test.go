@@ -0,0 +1,187 @@
+package rest_test
+
+import (
+	"compress/flate"
+	"compress/gzip"
+	"github.com/edermanoel94/rest-go"
+	"github.com/stretchr/testify/assert"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func largeProducts() []map[string]string {
+
+	products := make([]map[string]string, 100)
+
+	for i := range products {
+		products[i] = map[string]string{"name": "smart tv", "category": "electronics"}
+	}
+
+	return products
+}
+
+func TestCompress(t *testing.T) {
+
+	jsonHandler := rest.Compress(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("small") != "" {
+			rest.Marshalled(w, map[string]string{"name": "tv"}, http.StatusOK)
+			return
+		}
+		rest.Marshalled(w, largeProducts(), http.StatusOK)
+	}))
+
+	t.Run("should compress large json with gzip", func(t *testing.T) {
+
+		request := httptest.NewRequest(http.MethodGet, "/", nil)
+		request.Header.Set("Accept-Encoding", "deflate;q=0.5, gzip")
+
+		recorder := httptest.NewRecorder()
+
+		jsonHandler.ServeHTTP(recorder, request)
+
+		assert.Equal(t, http.StatusOK, recorder.Code)
+		assert.Equal(t, "gzip", recorder.Header().Get("Content-Encoding"))
+		assert.Equal(t, "Accept-Encoding", recorder.Header().Get("Vary"))
+
+		reader, err := gzip.NewReader(recorder.Body)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		body, _ := ioutil.ReadAll(reader)
+
+		assert.True(t, strings.HasPrefix(string(body), `[{"category":"electronics","name":"smart tv"}`))
+	})
+
+	t.Run("should compress with deflate when preferred", func(t *testing.T) {
+
+		request := httptest.NewRequest(http.MethodGet, "/", nil)
+		request.Header.Set("Accept-Encoding", "deflate, gzip;q=0.1")
+
+		recorder := httptest.NewRecorder()
+
+		jsonHandler.ServeHTTP(recorder, request)
+
+		assert.Equal(t, "deflate", recorder.Header().Get("Content-Encoding"))
+
+		body, _ := ioutil.ReadAll(flate.NewReader(recorder.Body))
+
+		assert.True(t, strings.HasPrefix(string(body), `[{"category"`))
+	})
+
+	t.Run("should not compress small bodies", func(t *testing.T) {
+
+		request := httptest.NewRequest(http.MethodGet, "/?small=true", nil)
+		request.Header.Set("Accept-Encoding", "gzip")
+
+		recorder := httptest.NewRecorder()
+
+		jsonHandler.ServeHTTP(recorder, request)
+
+		assert.Empty(t, recorder.Header().Get("Content-Encoding"))
+		assert.Equal(t, "Accept-Encoding", recorder.Header().Get("Vary"))
+		assert.Equal(t, `{"name":"tv"}`, recorder.Body.String())
+	})
+
+	t.Run("should not compress when client don't accept", func(t *testing.T) {
+
+		request := httptest.NewRequest(http.MethodGet, "/", nil)
+		request.Header.Set("Accept-Encoding", "identity")
+
+		recorder := httptest.NewRecorder()
+
+		jsonHandler.ServeHTTP(recorder, request)
+
+		assert.Empty(t, recorder.Header().Get("Content-Encoding"))
+		assert.True(t, strings.HasPrefix(recorder.Body.String(), `[{"category"`))
+	})
+
+	t.Run("should not compress content types not allowed", func(t *testing.T) {
+
+		handler := rest.Compress(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("Content-Type", "image/png")
+			io.Copy(w, strings.NewReader(strings.Repeat("x", 2048)))
+		}))
+
+		request := httptest.NewRequest(http.MethodGet, "/", nil)
+		request.Header.Set("Accept-Encoding", "gzip")
+
+		recorder := httptest.NewRecorder()
+
+		handler.ServeHTTP(recorder, request)
+
+		assert.Empty(t, recorder.Header().Get("Content-Encoding"))
+		assert.Equal(t, 2048, recorder.Body.Len())
+	})
+
+	t.Run("should compress what was flushed", func(t *testing.T) {
+
+		handler := rest.CompressWith(rest.CompressOptions{MinSize: 1, ContentTypes: []string{"application/x-ndjson"}})(
+			http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				stream := rest.Stream(w)
+				stream.Send(1)
+				stream.Send(2)
+				stream.Close()
+			}))
+
+		request := httptest.NewRequest(http.MethodGet, "/", nil)
+		request.Header.Set("Accept-Encoding", "gzip")
+
+		recorder := httptest.NewRecorder()
+
+		handler.ServeHTTP(recorder, request)
+
+		assert.Equal(t, "gzip", recorder.Header().Get("Content-Encoding"))
+		assert.True(t, recorder.Flushed)
+
+		reader, err := gzip.NewReader(recorder.Body)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		body, _ := ioutil.ReadAll(reader)
+
+		assert.Equal(t, "1\n2\n", string(body))
+	})
+
+	t.Run("should prefer a registered compressor", func(t *testing.T) {
+
+		rest.RegisterCompressor("br", func(w io.Writer) io.WriteCloser {
+			// a real brotli writer, like andybalholm/brotli, is registered the same way
+			return gzip.NewWriter(w)
+		})
+
+		request := httptest.NewRequest(http.MethodGet, "/", nil)
+		request.Header.Set("Accept-Encoding", "gzip, br")
+
+		recorder := httptest.NewRecorder()
+
+		jsonHandler.ServeHTTP(recorder, request)
+
+		assert.Equal(t, "br", recorder.Header().Get("Content-Encoding"))
+	})
+
+	t.Run("should not send body on not modified", func(t *testing.T) {
+
+		handler := rest.Compress(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusNotModified)
+		}))
+
+		request := httptest.NewRequest(http.MethodGet, "/", nil)
+		request.Header.Set("Accept-Encoding", "gzip")
+
+		recorder := httptest.NewRecorder()
+
+		handler.ServeHTTP(recorder, request)
+
+		assert.Equal(t, http.StatusNotModified, recorder.Code)
+		assert.Empty(t, recorder.Header().Get("Content-Encoding"))
+		assert.Equal(t, 0, recorder.Body.Len())
+	})
+}
@@ -0,0 +1,93 @@
+package rest
+
+import (
+	"net/http"
+	"time"
+)
+
+// CookieOption customize a cookie created by SetCookie
+type CookieOption func(*http.Cookie)
+
+// SetCookie add a cookie on response, by default is Secure, HttpOnly, SameSite=Lax and Path=/
+func SetCookie(w http.ResponseWriter, name, value string, opts ...CookieOption) {
+
+	cookie := &http.Cookie{
+		Name:     name,
+		Value:    value,
+		Path:     "/",
+		Secure:   true,
+		HttpOnly: true,
+		SameSite: http.SameSiteLaxMode,
+	}
+
+	for _, opt := range opts {
+		opt(cookie)
+	}
+
+	http.SetCookie(w, cookie)
+}
+
+// DeleteCookie expire a cookie on client, path and domain must match with the cookie created
+func DeleteCookie(w http.ResponseWriter, name string, opts ...CookieOption) {
+	SetCookie(w, name, "", append(opts, func(cookie *http.Cookie) {
+		cookie.MaxAge = -1
+		cookie.Expires = time.Unix(0, 0)
+	})...)
+}
+
+// CookieMaxAge set Max-Age on cookie, duration must be at least one second
+func CookieMaxAge(d time.Duration) CookieOption {
+	return func(cookie *http.Cookie) {
+		cookie.MaxAge = int(d.Seconds())
+	}
+}
+
+// CookieExpires set Expires on cookie
+func CookieExpires(t time.Time) CookieOption {
+	return func(cookie *http.Cookie) {
+		cookie.Expires = t
+	}
+}
+
+// CookieExpiresIn set Max-Age and Expires on cookie, old clients which don't support Max-Age use Expires
+func CookieExpiresIn(d time.Duration) CookieOption {
+	return func(cookie *http.Cookie) {
+		CookieMaxAge(d)(cookie)
+		CookieExpires(time.Now().Add(d))(cookie)
+	}
+}
+
+// CookiePath set Path on cookie
+func CookiePath(path string) CookieOption {
+	return func(cookie *http.Cookie) {
+		cookie.Path = path
+	}
+}
+
+// CookieDomain set Domain on cookie
+func CookieDomain(domain string) CookieOption {
+	return func(cookie *http.Cookie) {
+		cookie.Domain = domain
+	}
+}
+
+// CookieSameSite change the SameSite mode of cookie
+func CookieSameSite(mode http.SameSite) CookieOption {
+	return func(cookie *http.Cookie) {
+		cookie.SameSite = mode
+	}
+}
+
+// CookieInsecure allow cookie to be sent without https, use just on local development
+func CookieInsecure() CookieOption {
+	return func(cookie *http.Cookie) {
+		cookie.Secure = false
+	}
+}
+
+// CookieScriptAccess allow javascript to read the cookie, removing HttpOnly
+func CookieScriptAccess() CookieOption {
+	return func(cookie *http.Cookie) {
+		cookie.HttpOnly = false
+	}
+}
@@ -0,0 +1,81 @@
+package rest_test
+
+import (
+	"github.com/edermanoel94/rest-go"
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestSetCookie(t *testing.T) {
+
+	t.Run("should create cookie with secure defaults", func(t *testing.T) {
+
+		recorder := httptest.NewRecorder()
+
+		rest.SetCookie(recorder, "session", "abc")
+
+		cookies := recorder.Result().Cookies()
+
+		if len(cookies) != 1 {
+			t.Fatalf("expected one cookie, got: %d", len(cookies))
+		}
+
+		assert.Equal(t, "session", cookies[0].Name)
+		assert.Equal(t, "abc", cookies[0].Value)
+		assert.Equal(t, "/", cookies[0].Path)
+		assert.True(t, cookies[0].Secure)
+		assert.True(t, cookies[0].HttpOnly)
+		assert.Equal(t, http.SameSiteLaxMode, cookies[0].SameSite)
+	})
+
+	t.Run("should apply options", func(t *testing.T) {
+
+		recorder := httptest.NewRecorder()
+
+		rest.SetCookie(recorder, "theme", "dark",
+			rest.CookieMaxAge(time.Hour),
+			rest.CookiePath("/app"),
+			rest.CookieDomain("example.com"),
+			rest.CookieSameSite(http.SameSiteStrictMode),
+			rest.CookieInsecure(),
+			rest.CookieScriptAccess())
+
+		cookie := recorder.Result().Cookies()[0]
+
+		assert.Equal(t, 3600, cookie.MaxAge)
+		assert.Equal(t, "/app", cookie.Path)
+		assert.Equal(t, "example.com", cookie.Domain)
+		assert.Equal(t, http.SameSiteStrictMode, cookie.SameSite)
+		assert.False(t, cookie.Secure)
+		assert.False(t, cookie.HttpOnly)
+	})
+
+	t.Run("should set max age and expires together", func(t *testing.T) {
+
+		recorder := httptest.NewRecorder()
+
+		rest.SetCookie(recorder, "theme", "dark", rest.CookieExpiresIn(time.Minute))
+
+		cookie := recorder.Result().Cookies()[0]
+
+		assert.Equal(t, 60, cookie.MaxAge)
+		assert.WithinDuration(t, time.Now().Add(time.Minute), cookie.Expires, 2*time.Second)
+	})
+}
+
+func TestDeleteCookie(t *testing.T) {
+
+	recorder := httptest.NewRecorder()
+
+	rest.DeleteCookie(recorder, "session")
+
+	cookie := recorder.Result().Cookies()[0]
+
+	assert.Equal(t, "session", cookie.Name)
+	assert.Equal(t, "", cookie.Value)
+	assert.Equal(t, -1, cookie.MaxAge)
+	assert.Contains(t, recorder.Header().Get("Set-Cookie"), "Max-Age=0")
+}
@@ -0,0 +1,182 @@
+package rest
+
+import (
+	"errors"
+	"net/http"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ErrOriginNotAllowed is sent on a preflight of a origin, method or header not allowed
+var ErrOriginNotAllowed = errors.New("cross origin request not allowed")
+
+// CORSConfig configure the CORS middleware
+type CORSConfig struct {
+	// AllowedOrigins are exact origins, * to allow any origin, or a wildcard like https://*.example.com
+	AllowedOrigins []string
+	// AllowedOriginPatterns are regular expressions matched against the origin
+	AllowedOriginPatterns []*regexp.Regexp
+	// AllowedMethods are the methods of preflight, DefaultCORSMethods when empty
+	AllowedMethods []string
+	// AllowedHeaders are the headers of preflight, * to allow any header requested
+	AllowedHeaders   []string
+	ExposedHeaders   []string
+	AllowCredentials bool
+	MaxAge           time.Duration
+}
+
+// DefaultCORSMethods are the methods allowed when CORSConfig.AllowedMethods is empty
+var DefaultCORSMethods = []string{http.MethodGet, http.MethodHead, http.MethodPost, http.MethodPut, http.MethodPatch, http.MethodDelete}
+
+// CORS is a middleware which answer preflights with 204 and set Access-Control-Allow-Origin on responses
+// of allowed origins. A preflight not allowed is answered with 403.
+func CORS(config CORSConfig) func(http.Handler) http.Handler {
+
+	methods := config.AllowedMethods
+
+	if len(methods) == 0 {
+		methods = DefaultCORSMethods
+	}
+
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+
+			header := w.Header()
+
+			header.Add("Vary", "Origin")
+
+			origin := r.Header.Get("Origin")
+
+			preflight := r.Method == http.MethodOptions && r.Header.Get("Access-Control-Request-Method") != ""
+
+			if origin == "" {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			if !config.allowOrigin(origin) {
+				if preflight {
+					Error(w, ErrOriginNotAllowed, http.StatusForbidden)
+					return
+				}
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			config.setOrigin(header, origin)
+
+			if !preflight {
+				if len(config.ExposedHeaders) > 0 {
+					header.Set("Access-Control-Expose-Headers", strings.Join(config.ExposedHeaders, ", "))
+				}
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			header.Add("Vary", "Access-Control-Request-Method")
+			header.Add("Vary", "Access-Control-Request-Headers")
+
+			method := strings.ToUpper(r.Header.Get("Access-Control-Request-Method"))
+
+			requested := parseHeaderList(r.Header.Get("Access-Control-Request-Headers"))
+
+			if !containsFold(methods, method) || !config.allowHeaders(requested) {
+				header.Del("Access-Control-Allow-Origin")
+				header.Del("Access-Control-Allow-Credentials")
+				Error(w, ErrOriginNotAllowed, http.StatusForbidden)
+				return
+			}
+
+			header.Set("Access-Control-Allow-Methods", strings.Join(methods, ", "))
+
+			if len(requested) > 0 {
+				header.Set("Access-Control-Allow-Headers", strings.Join(requested, ", "))
+			}
+
+			if config.MaxAge > 0 {
+				header.Set("Access-Control-Max-Age", strconv.FormatInt(int64(config.MaxAge/time.Second), 10))
+			}
+
+			NoContent(w)
+		})
+	}
+}
+
+func (c *CORSConfig) allowOrigin(origin string) bool {
+
+	for _, allowed := range c.AllowedOrigins {
+
+		if allowed == "*" || strings.EqualFold(allowed, origin) {
+			return true
+		}
+
+		if i := strings.Index(allowed, "*"); i >= 0 {
+			prefix, suffix := allowed[:i], allowed[i+1:]
+			if len(origin) >= len(prefix)+len(suffix) && strings.HasPrefix(origin, prefix) && strings.HasSuffix(origin, suffix) {
+				return true
+			}
+		}
+	}
+
+	for _, pattern := range c.AllowedOriginPatterns {
+		if pattern.MatchString(origin) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// setOrigin send * when any origin is allowed without credentials, and the origin otherwise
+func (c *CORSConfig) setOrigin(header http.Header, origin string) {
+
+	if !c.AllowCredentials && containsFold(c.AllowedOrigins, "*") {
+		header.Set("Access-Control-Allow-Origin", "*")
+		return
+	}
+
+	header.Set("Access-Control-Allow-Origin", origin)
+
+	if c.AllowCredentials {
+		header.Set("Access-Control-Allow-Credentials", "true")
+	}
+}
+
+func (c *CORSConfig) allowHeaders(requested []string) bool {
+
+	if containsFold(c.AllowedHeaders, "*") {
+		return true
+	}
+
+	for _, name := range requested {
+		if !containsFold(c.AllowedHeaders, name) {
+			return false
+		}
+	}
+
+	return true
+}
+
+func parseHeaderList(value string) []string {
+
+	var names []string
+
+	for _, name := range strings.Split(value, ",") {
+		if name = strings.TrimSpace(name); name != "" {
+			names = append(names, http.CanonicalHeaderKey(name))
+		}
+	}
+
+	return names
+}
+
+func containsFold(values []string, value string) bool {
+	for _, v := range values {
+		if strings.EqualFold(v, value) {
+			return true
+		}
+	}
+	return false
+}
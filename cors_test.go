@@ -0,0 +1,138 @@
+package rest_test
+
+import (
+	"github.com/edermanoel94/rest-go"
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"net/http/httptest"
+	"regexp"
+	"testing"
+	"time"
+)
+
+func TestCORS(t *testing.T) {
+
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		rest.Marshalled(w, []int{1}, http.StatusOK)
+	})
+
+	config := rest.CORSConfig{
+		AllowedOrigins:        []string{"https://app.example.com", "https://*.example.org"},
+		AllowedOriginPatterns: []*regexp.Regexp{regexp.MustCompile(`^http://localhost:\d+$`)},
+		AllowedHeaders:        []string{"Content-Type", "Authorization"},
+		ExposedHeaders:        []string{"X-Request-ID"},
+		AllowCredentials:      true,
+		MaxAge:                10 * time.Minute,
+	}
+
+	cors := rest.CORS(config)(handler)
+
+	originCases := []struct {
+		description string
+		origin      string
+		allowed     bool
+	}{
+		{"should allow a exact origin", "https://app.example.com", true},
+		{"should allow a wildcard origin", "https://shop.example.org", true},
+		{"should allow a origin matching pattern", "http://localhost:3000", true},
+		{"should not allow other origins", "https://evil.com", false},
+		{"should not allow the domain of wildcard", "https://example.org", false},
+	}
+
+	for _, tc := range originCases {
+
+		t.Run(tc.description, func(t *testing.T) {
+
+			request := httptest.NewRequest(http.MethodGet, "/products", nil)
+			request.Header.Set("Origin", tc.origin)
+
+			recorder := httptest.NewRecorder()
+
+			cors.ServeHTTP(recorder, request)
+
+			assert.Equal(t, http.StatusOK, recorder.Code)
+			assert.Equal(t, "Origin", recorder.Header().Get("Vary"))
+
+			if tc.allowed {
+				assert.Equal(t, tc.origin, recorder.Header().Get("Access-Control-Allow-Origin"))
+				assert.Equal(t, "true", recorder.Header().Get("Access-Control-Allow-Credentials"))
+				assert.Equal(t, "X-Request-ID", recorder.Header().Get("Access-Control-Expose-Headers"))
+			} else {
+				assert.Empty(t, recorder.Header().Get("Access-Control-Allow-Origin"))
+			}
+		})
+	}
+
+	t.Run("should answer a preflight", func(t *testing.T) {
+
+		request := httptest.NewRequest(http.MethodOptions, "/products", nil)
+		request.Header.Set("Origin", "https://app.example.com")
+		request.Header.Set("Access-Control-Request-Method", "PATCH")
+		request.Header.Set("Access-Control-Request-Headers", "content-type, authorization")
+
+		recorder := httptest.NewRecorder()
+
+		cors.ServeHTTP(recorder, request)
+
+		assert.Equal(t, http.StatusNoContent, recorder.Code)
+		assert.Equal(t, "https://app.example.com", recorder.Header().Get("Access-Control-Allow-Origin"))
+		assert.Equal(t, "GET, HEAD, POST, PUT, PATCH, DELETE", recorder.Header().Get("Access-Control-Allow-Methods"))
+		assert.Equal(t, "Content-Type, Authorization", recorder.Header().Get("Access-Control-Allow-Headers"))
+		assert.Equal(t, "600", recorder.Header().Get("Access-Control-Max-Age"))
+		assert.Equal(t, []string{"Origin", "Access-Control-Request-Method", "Access-Control-Request-Headers"}, recorder.Header()["Vary"])
+		assert.Equal(t, 0, recorder.Body.Len())
+	})
+
+	preflightCases := []struct {
+		description string
+		origin      string
+		method      string
+		headers     string
+	}{
+		{"should forbid a preflight of other origin", "https://evil.com", "GET", ""},
+		{"should forbid a preflight of method not allowed", "https://app.example.com", "TRACE", ""},
+		{"should forbid a preflight of header not allowed", "https://app.example.com", "GET", "X-Debug"},
+	}
+
+	for _, tc := range preflightCases {
+
+		t.Run(tc.description, func(t *testing.T) {
+
+			request := httptest.NewRequest(http.MethodOptions, "/products", nil)
+			request.Header.Set("Origin", tc.origin)
+			request.Header.Set("Access-Control-Request-Method", tc.method)
+			request.Header.Set("Access-Control-Request-Headers", tc.headers)
+
+			recorder := httptest.NewRecorder()
+
+			cors.ServeHTTP(recorder, request)
+
+			assert.Equal(t, http.StatusForbidden, recorder.Code)
+			assert.Empty(t, recorder.Header().Get("Access-Control-Allow-Origin"))
+			assert.Equal(t, `{"message":"cross origin request not allowed"}`, recorder.Body.String())
+		})
+	}
+
+	t.Run("should send any origin without credentials", func(t *testing.T) {
+
+		request := httptest.NewRequest(http.MethodGet, "/products", nil)
+		request.Header.Set("Origin", "https://any.com")
+
+		recorder := httptest.NewRecorder()
+
+		rest.CORS(rest.CORSConfig{AllowedOrigins: []string{"*"}})(handler).ServeHTTP(recorder, request)
+
+		assert.Equal(t, "*", recorder.Header().Get("Access-Control-Allow-Origin"))
+		assert.Empty(t, recorder.Header().Get("Access-Control-Allow-Credentials"))
+	})
+
+	t.Run("should not change requests without origin", func(t *testing.T) {
+
+		recorder := httptest.NewRecorder()
+
+		cors.ServeHTTP(recorder, httptest.NewRequest(http.MethodOptions, "/products", nil))
+
+		assert.Equal(t, http.StatusOK, recorder.Code)
+		assert.Empty(t, recorder.Header().Get("Access-Control-Allow-Origin"))
+	})
+}
@@ -0,0 +1,194 @@
+package rest
+
+import (
+	"context"
+	"encoding"
+	"encoding/csv"
+	"fmt"
+	"net/http"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+const textCsv = "text/csv; charset=utf-8"
+
+// DefaultCSVFilename is the name of file sent by CSV, use Attachment to change it
+const DefaultCSVFilename = "export.csv"
+
+type csvColumn struct {
+	name  string
+	index []int
+}
+
+// CSV send a slice of structs as csv, the first record have the column names of csv tag, or
+// the field name when untagged, fields with csv:"-" are skipped. Records are streamed as
+// they are encoded, the status is sent before the first record.
+func CSV(w http.ResponseWriter, rows interface{}, code int, opts ...Option) (int, error) {
+
+	c := newCall(context.Background(), opts...)
+
+	value := reflect.ValueOf(rows)
+
+	if value.Kind() != reflect.Slice && value.Kind() != reflect.Array {
+		return c.fail(w, fmt.Errorf("cannot send %T as csv, must be a slice of structs", rows), http.StatusInternalServerError)
+	}
+
+	elem := value.Type().Elem()
+
+	if elem.Kind() == reflect.Ptr {
+		elem = elem.Elem()
+	}
+
+	if elem.Kind() != reflect.Struct {
+		return c.fail(w, fmt.Errorf("cannot send %T as csv, must be a slice of structs", rows), http.StatusInternalServerError)
+	}
+
+	columns := csvColumns(elem, nil)
+
+	header := w.Header()
+
+	applyDefaultHeaders(header)
+	header.Set("Content-Disposition", attachment(DefaultCSVFilename))
+
+	c.opts.apply(header, code)
+
+	mediaType := c.opts.mediaType(textCsv)
+
+	header.Set(contentType, mediaType)
+
+	rw := newResponseWriter(w)
+	rw.WriteHeader(code)
+
+	writer := csv.NewWriter(rw)
+
+	record := make([]string, len(columns))
+
+	for i, column := range columns {
+		record[i] = column.name
+	}
+
+	writer.Write(record)
+
+	for i := 0; i < value.Len(); i++ {
+
+		row := value.Index(i)
+
+		if row.Kind() == reflect.Ptr {
+			if row.IsNil() {
+				continue
+			}
+			row = row.Elem()
+		}
+
+		for j, column := range columns {
+			record[j] = csvValue(row, column.index)
+		}
+
+		if err := writer.Write(record); err != nil {
+			break
+		}
+	}
+
+	writer.Flush()
+
+	err := writer.Error()
+
+	c.notify(mediaType, code, int(rw.written), err)
+
+	return int(rw.written), err
+}
+
+// csvColumns return the exported fields of struct, embedded structs without tag are flattened
+func csvColumns(t reflect.Type, parent []int) []csvColumn {
+
+	var columns []csvColumn
+
+	for i := 0; i < t.NumField(); i++ {
+
+		field := t.Field(i)
+
+		tag := field.Tag.Get("csv")
+
+		if tag == "-" {
+			continue
+		}
+
+		index := append(append([]int{}, parent...), i)
+
+		fieldType := field.Type
+
+		if fieldType.Kind() == reflect.Ptr {
+			fieldType = fieldType.Elem()
+		}
+
+		if field.Anonymous && tag == "" && fieldType.Kind() == reflect.Struct {
+			columns = append(columns, csvColumns(fieldType, index)...)
+			continue
+		}
+
+		if field.PkgPath != "" {
+			continue
+		}
+
+		name := strings.Split(tag, ",")[0]
+
+		if name == "" {
+			name = field.Name
+		}
+
+		columns = append(columns, csvColumn{name: name, index: index})
+	}
+
+	return columns
+}
+
+// csvValue format a field of row, a nil pointer on the path is a empty value
+func csvValue(row reflect.Value, index []int) string {
+
+	v := row
+
+	for _, i := range index {
+
+		if v.Kind() == reflect.Ptr {
+			if v.IsNil() {
+				return ""
+			}
+			v = v.Elem()
+		}
+
+		v = v.Field(i)
+	}
+
+	if v.Kind() == reflect.Ptr {
+		if v.IsNil() {
+			return ""
+		}
+		v = v.Elem()
+	}
+
+	if v.Type().Implements(textMarshalerType) {
+		text, err := v.Interface().(encoding.TextMarshaler).MarshalText()
+		if err != nil {
+			return ""
+		}
+		return string(text)
+	}
+
+	switch v.Kind() {
+	case reflect.String:
+		return v.String()
+	case reflect.Bool:
+		return strconv.FormatBool(v.Bool())
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return strconv.FormatInt(v.Int(), 10)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return strconv.FormatUint(v.Uint(), 10)
+	case reflect.Float32:
+		return strconv.FormatFloat(v.Float(), 'f', -1, 32)
+	case reflect.Float64:
+		return strconv.FormatFloat(v.Float(), 'f', -1, 64)
+	}
+
+	return fmt.Sprint(v.Interface())
+}
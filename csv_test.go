@@ -0,0 +1,91 @@
+package rest_test
+
+import (
+	"github.com/edermanoel94/rest-go"
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+type csvAudit struct {
+	CreatedAt time.Time `csv:"created_at"`
+}
+
+type csvProduct struct {
+	ID       int     `csv:"id"`
+	Name     string  `csv:"name"`
+	Price    float64 `csv:"price"`
+	Discount *int    `csv:"discount"`
+	Secret   string  `csv:"-"`
+	Active   bool
+	internal string
+	csvAudit
+}
+
+func TestCSV(t *testing.T) {
+
+	createdAt := time.Date(2020, 1, 2, 3, 4, 5, 0, time.UTC)
+
+	discount := 10
+
+	products := []csvProduct{
+		{ID: 1, Name: "tv, 42\"", Price: 999.9, Discount: &discount, Secret: "x", Active: true, csvAudit: csvAudit{createdAt}},
+		{ID: 2, Name: "radio", Price: 10, csvAudit: csvAudit{createdAt}},
+	}
+
+	t.Run("should send rows as csv", func(t *testing.T) {
+
+		recorder := httptest.NewRecorder()
+
+		_, err := rest.CSV(recorder, products, http.StatusOK)
+
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusOK, recorder.Code)
+		assert.Equal(t, "text/csv; charset=utf-8", recorder.Header().Get("Content-Type"))
+		assert.Equal(t, "attachment; filename=export.csv", recorder.Header().Get("Content-Disposition"))
+		assert.Equal(t, "id,name,price,discount,Active,created_at\n"+
+			"1,\"tv, 42\"\"\",999.9,10,true,2020-01-02T03:04:05Z\n"+
+			"2,radio,10,,false,2020-01-02T03:04:05Z\n", recorder.Body.String())
+	})
+
+	t.Run("should send rows given by pointer", func(t *testing.T) {
+
+		recorder := httptest.NewRecorder()
+
+		rest.CSV(recorder, []*csvProduct{&products[1], nil}, http.StatusOK)
+
+		assert.Equal(t, "id,name,price,discount,Active,created_at\n"+
+			"2,radio,10,,false,2020-01-02T03:04:05Z\n", recorder.Body.String())
+	})
+
+	t.Run("should send the filename given", func(t *testing.T) {
+
+		recorder := httptest.NewRecorder()
+
+		rest.CSV(recorder, products, http.StatusOK, rest.Attachment("products.csv"))
+
+		assert.Equal(t, "attachment; filename=products.csv", recorder.Header().Get("Content-Disposition"))
+	})
+
+	t.Run("should send only the header without rows", func(t *testing.T) {
+
+		recorder := httptest.NewRecorder()
+
+		rest.CSV(recorder, []csvProduct{}, http.StatusOK)
+
+		assert.Equal(t, "id,name,price,discount,Active,created_at\n", recorder.Body.String())
+	})
+
+	t.Run("should send a error when is not a slice of structs", func(t *testing.T) {
+
+		recorder := httptest.NewRecorder()
+
+		rest.CSV(recorder, []int{1}, http.StatusOK)
+
+		assert.Equal(t, http.StatusInternalServerError, recorder.Code)
+		assert.Equal(t, "application/json", recorder.Header().Get("Content-Type"))
+		assert.Empty(t, recorder.Header().Get("Content-Disposition"))
+	})
+}
@@ -0,0 +1,75 @@
+package rest
+
+import (
+	"context"
+	"encoding/base64"
+	"fmt"
+	"net/http"
+)
+
+// cursorPage is the envelope of a cursor paginated response
+type cursorPage struct {
+	Data       interface{} `json:"data"`
+	NextCursor *string     `json:"next_cursor"`
+}
+
+// CursorError is returned by DecodeCursor when the cursor was not created by EncodeCursor,
+// rendered as 400 by Error
+type CursorError struct {
+	Err error
+}
+
+func (c *CursorError) Error() string {
+	return fmt.Sprintf("invalid cursor: %v", c.Err)
+}
+
+// Unwrap return the cause of error
+func (c *CursorError) Unwrap() error {
+	return c.Err
+}
+
+// StatusCode make Error respond with 400
+func (c *CursorError) StatusCode() int {
+	return http.StatusBadRequest
+}
+
+// Cursor respond items inside of a envelope {"data": [...], "next_cursor": "..."}, a empty
+// nextCursor is sent as null, meaning there is no more items
+func Cursor(w http.ResponseWriter, items interface{}, nextCursor string, code int) (int, error) {
+
+	body := &cursorPage{Data: items}
+
+	if nextCursor != "" {
+		body.NextCursor = &nextCursor
+	}
+
+	return newCall(context.Background()).marshalled(w, body, code)
+}
+
+// EncodeCursor create a opaque cursor from a value, like the sort keys of last item
+func EncodeCursor(v interface{}) (string, error) {
+
+	bytes, err := marshalJson(v)
+
+	if err != nil {
+		return "", fmt.Errorf("couldn't encode cursor: %w", err)
+	}
+
+	return base64.RawURLEncoding.EncodeToString(bytes), nil
+}
+
+// DecodeCursor decode a cursor created by EncodeCursor on a pointer, returning a *CursorError
+func DecodeCursor(cursor string, v interface{}) error {
+
+	bytes, err := base64.RawURLEncoding.DecodeString(cursor)
+
+	if err != nil {
+		return &CursorError{Err: err}
+	}
+
+	if err := unmarshalJson(bytes, v); err != nil {
+		return &CursorError{Err: err}
+	}
+
+	return nil
+}
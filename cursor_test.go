@@ -0,0 +1,77 @@
+package rest_test
+
+import (
+	"errors"
+	"github.com/edermanoel94/rest-go"
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+type productCursor struct {
+	ID      int       `json:"id"`
+	Created time.Time `json:"created"`
+}
+
+func TestCursor(t *testing.T) {
+
+	t.Run("should send items with next cursor", func(t *testing.T) {
+
+		recorder := httptest.NewRecorder()
+
+		rest.Cursor(recorder, []int{1, 2}, "abc", http.StatusOK)
+
+		assert.Equal(t, `{"data":[1,2],"next_cursor":"abc"}`, recorder.Body.String())
+	})
+
+	t.Run("should send null when there is no more items", func(t *testing.T) {
+
+		recorder := httptest.NewRecorder()
+
+		rest.Cursor(recorder, []int{3}, "", http.StatusOK)
+
+		assert.Equal(t, `{"data":[3],"next_cursor":null}`, recorder.Body.String())
+	})
+}
+
+func TestEncodeCursor(t *testing.T) {
+
+	t.Run("should decode a encoded cursor", func(t *testing.T) {
+
+		expected := productCursor{ID: 42, Created: time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)}
+
+		cursor, err := rest.EncodeCursor(expected)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		actual := productCursor{}
+
+		err = rest.DecodeCursor(cursor, &actual)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, expected, actual)
+		assert.NotContains(t, cursor, "=")
+	})
+
+	t.Run("should respond 400 on invalid cursor", func(t *testing.T) {
+
+		err := rest.DecodeCursor("not a cursor!", &productCursor{})
+
+		var cursorErr *rest.CursorError
+
+		assert.True(t, errors.As(err, &cursorErr))
+
+		recorder := httptest.NewRecorder()
+
+		rest.Error(recorder, err, 0)
+
+		assert.Equal(t, http.StatusBadRequest, recorder.Code)
+	})
+}
@@ -0,0 +1,62 @@
+package rest
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"runtime/debug"
+	"sync/atomic"
+)
+
+var debugMode int32
+
+type debugKey struct{}
+
+// debugInfo is sent on debug field of errors when debug mode is enabled
+type debugInfo struct {
+	Causes []debugCause `json:"causes"`
+	Stack  string       `json:"stack"`
+}
+
+type debugCause struct {
+	Type    string `json:"type"`
+	Message string `json:"message"`
+}
+
+// SetDebug enable the debug field on every error, with the chain of causes and the stack trace
+// where the error was sent. Must not be enabled on production, it leaks internals of server.
+func SetDebug(enabled bool) {
+	var value int32
+	if enabled {
+		value = 1
+	}
+	atomic.StoreInt32(&debugMode, value)
+}
+
+// WithDebug enable or disable the debug mode for a request, overriding SetDebug, used by ErrorCtx
+func WithDebug(ctx context.Context, enabled bool) context.Context {
+	return context.WithValue(ctx, debugKey{}, enabled)
+}
+
+func debugEnabled(ctx context.Context) bool {
+
+	if ctx != nil {
+		if enabled, ok := ctx.Value(debugKey{}).(bool); ok {
+			return enabled
+		}
+	}
+
+	return atomic.LoadInt32(&debugMode) == 1
+}
+
+// newDebugInfo unwrap the causes of err, from the error sent to the root cause
+func newDebugInfo(err error) *debugInfo {
+
+	info := &debugInfo{Stack: string(debug.Stack())}
+
+	for ; err != nil; err = errors.Unwrap(err) {
+		info.Causes = append(info.Causes, debugCause{Type: fmt.Sprintf("%T", err), Message: err.Error()})
+	}
+
+	return info
+}
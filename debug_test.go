@@ -0,0 +1,80 @@
+package rest_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/edermanoel94/rest-go"
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+type debugBody struct {
+	Message string `json:"message"`
+	Debug   *struct {
+		Causes []struct {
+			Type    string `json:"type"`
+			Message string `json:"message"`
+		} `json:"causes"`
+		Stack string `json:"stack"`
+	} `json:"debug"`
+}
+
+func TestSetDebug(t *testing.T) {
+
+	errNotFound := errors.New("no rows")
+
+	t.Run("should not send debug by default", func(t *testing.T) {
+
+		recorder := httptest.NewRecorder()
+
+		rest.Error(recorder, fmt.Errorf("couldn't find product: %w", errNotFound), http.StatusNotFound)
+
+		assert.Equal(t, `{"message":"couldn't find product: no rows"}`, recorder.Body.String())
+	})
+
+	t.Run("should send causes and stack on debug mode", func(t *testing.T) {
+
+		rest.SetDebug(true)
+		defer rest.SetDebug(false)
+
+		recorder := httptest.NewRecorder()
+
+		rest.Error(recorder, fmt.Errorf("couldn't find product: %w", errNotFound), http.StatusNotFound)
+
+		var body debugBody
+
+		err := json.Unmarshal(recorder.Body.Bytes(), &body)
+
+		assert.NoError(t, err)
+		assert.Equal(t, "couldn't find product: no rows", body.Message)
+		assert.Len(t, body.Debug.Causes, 2)
+		assert.Equal(t, "*fmt.wrapError", body.Debug.Causes[0].Type)
+		assert.Equal(t, "no rows", body.Debug.Causes[1].Message)
+		assert.Contains(t, body.Debug.Stack, "TestSetDebug")
+	})
+
+	t.Run("should enable debug mode for a request", func(t *testing.T) {
+
+		recorder := httptest.NewRecorder()
+
+		rest.ErrorCtx(rest.WithDebug(context.Background(), true), recorder, errNotFound, http.StatusNotFound)
+
+		assert.Contains(t, recorder.Body.String(), `"debug":{"causes":[{"type":"*errors.errorString","message":"no rows"}]`)
+	})
+
+	t.Run("should disable debug mode for a request", func(t *testing.T) {
+
+		rest.SetDebug(true)
+		defer rest.SetDebug(false)
+
+		recorder := httptest.NewRecorder()
+
+		rest.ErrorCtx(rest.WithDebug(context.Background(), false), recorder, errNotFound, http.StatusNotFound)
+
+		assert.Equal(t, `{"message":"no rows"}`, recorder.Body.String())
+	})
+}
@@ -0,0 +1,25 @@
+package rest
+
+import (
+	"fmt"
+	"net/http"
+	"time"
+)
+
+// Deprecated mark the response as deprecated with Deprecation: true, the date when it stop
+// working on Sunset and the url of documentation on Link, like </docs/v2>; rel="deprecation".
+// A zero sunset or a empty link are not sent.
+func Deprecated(sunset time.Time, link string) Option {
+	return func(o *options) {
+
+		WithHeader("Deprecation", "true")(o)
+
+		if !sunset.IsZero() {
+			WithHeader("Sunset", sunset.UTC().Format(http.TimeFormat))(o)
+		}
+
+		if link != "" {
+			WithHeader("Link", fmt.Sprintf(`<%s>; rel="deprecation"`, link))(o)
+		}
+	}
+}
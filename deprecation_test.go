@@ -0,0 +1,55 @@
+package rest_test
+
+import (
+	"errors"
+	"github.com/edermanoel94/rest-go"
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestDeprecated(t *testing.T) {
+
+	sunset := time.Date(2030, 1, 2, 3, 4, 5, 0, time.FixedZone("BRT", -3*60*60))
+
+	testCases := []struct {
+		description string
+		sunset      time.Time
+		link        string
+		sunsetValue string
+		links       []string
+	}{
+		{"should send deprecation, sunset and link", sunset, "https://example.com/docs/v2",
+			"Wed, 02 Jan 2030 06:04:05 GMT", []string{`<https://example.com/docs/v2>; rel="deprecation"`}},
+		{"should not send sunset and link when empty", time.Time{}, "", "", nil},
+	}
+
+	for _, tc := range testCases {
+
+		t.Run(tc.description, func(t *testing.T) {
+
+			recorder := httptest.NewRecorder()
+
+			rest.Marshalled(recorder, []int{1}, http.StatusOK, rest.Deprecated(tc.sunset, tc.link))
+
+			assert.Equal(t, http.StatusOK, recorder.Code)
+			assert.Equal(t, "true", recorder.Header().Get("Deprecation"))
+			assert.Equal(t, tc.sunsetValue, recorder.Header().Get("Sunset"))
+			assert.Equal(t, tc.links, recorder.Header()["Link"])
+		})
+	}
+
+	t.Run("should keep other links and send on errors", func(t *testing.T) {
+
+		recorder := httptest.NewRecorder()
+
+		rest.Error(recorder, errors.New("not found"), http.StatusNotFound,
+			rest.WithHeader("Link", `</products?page=2>; rel="next"`), rest.Deprecated(sunset, "/docs/v2"))
+
+		assert.Equal(t, http.StatusNotFound, recorder.Code)
+		assert.Equal(t, "true", recorder.Header().Get("Deprecation"))
+		assert.Equal(t, []string{`</products?page=2>; rel="next"`, `</docs/v2>; rel="deprecation"`}, recorder.Header()["Link"])
+	})
+}
@@ -0,0 +1,22 @@
+package rest
+
+import (
+	"context"
+	"net/http"
+)
+
+// Encoded marshall v with the encoder registered for media type, see RegisterEncoder, errors are
+// sent on the same media type when its encoder can encode them, or on json
+func Encoded(w http.ResponseWriter, mediaType string, v interface{}, code int, opts ...Option) (int, error) {
+
+	c := newCall(context.Background(), opts...)
+	c.errorType = mediaType
+
+	bytes, err := encode(mediaType, v)
+
+	if err != nil {
+		return c.fail(w, err, http.StatusInternalServerError)
+	}
+
+	return c.write(w, mediaType, bytes, code)
+}
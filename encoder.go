@@ -0,0 +1,131 @@
+package rest
+
+import (
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"sync"
+)
+
+// Encoder marshal values to a media type
+type Encoder interface {
+	Encode(v interface{}) ([]byte, error)
+}
+
+// EncoderFunc is a function which implements Encoder
+type EncoderFunc func(v interface{}) ([]byte, error)
+
+// Encode call f(v)
+func (f EncoderFunc) Encode(v interface{}) ([]byte, error) {
+	return f(v)
+}
+
+// Validator can be implemented by a Encoder to check the bodies sent by Response
+type Validator interface {
+	Valid(body []byte) bool
+}
+
+type registeredEncoder struct {
+	mediaType string
+	encoder   Encoder
+}
+
+var (
+	encodersMu sync.RWMutex
+	// encoders on order of registration, the first is used when client accept anything
+	encoders = []registeredEncoder{
+		{mediaType: applicationJson, encoder: jsonEncoder{}},
+		{mediaType: applicationXml, encoder: EncoderFunc(xml.Marshal)},
+	}
+)
+
+// RegisterEncoder add a encoder for media type, or replace the encoder already registered.
+// Registering application/json change the encoder used by Marshalled and Error, SetMarshaler(nil)
+// restore the default one.
+func RegisterEncoder(mediaType string, enc Encoder) {
+
+	encodersMu.Lock()
+	defer encodersMu.Unlock()
+
+	for i, registered := range encoders {
+		if registered.mediaType == mediaType {
+			encoders[i].encoder = enc
+			return
+		}
+	}
+
+	encoders = append(encoders, registeredEncoder{mediaType: mediaType, encoder: enc})
+}
+
+// UnregisterEncoder remove the encoder of media type
+func UnregisterEncoder(mediaType string) {
+
+	encodersMu.Lock()
+	defer encodersMu.Unlock()
+
+	for i, registered := range encoders {
+		if registered.mediaType == mediaType {
+			encoders = append(encoders[:i:i], encoders[i+1:]...)
+			return
+		}
+	}
+}
+
+// lookupEncoder return the encoder of media type
+func lookupEncoder(mediaType string) (Encoder, bool) {
+
+	encodersMu.RLock()
+	defer encodersMu.RUnlock()
+
+	for _, registered := range encoders {
+		if registered.mediaType == mediaType {
+			return registered.encoder, true
+		}
+	}
+
+	return nil, false
+}
+
+// encode marshal v to media type with the registered encoder
+func encode(mediaType string, v interface{}) ([]byte, error) {
+
+	enc, ok := lookupEncoder(mediaType)
+
+	if !ok {
+		return nil, fmt.Errorf("no encoder registered for %s", mediaType)
+	}
+
+	return enc.Encode(v)
+}
+
+// valid check a body of media type, json bodies are checked by json.Valid unless the
+// registered encoder implements Validator
+func valid(mediaType string, body []byte) bool {
+
+	if enc, ok := lookupEncoder(mediaType); ok {
+		if validator, ok := enc.(Validator); ok {
+			return validator.Valid(body)
+		}
+	}
+
+	if mediaType == applicationJson {
+		return json.Valid(body)
+	}
+
+	return true
+}
+
+// mediaTypes return the media types of registered encoders
+func mediaTypes() []string {
+
+	encodersMu.RLock()
+	defer encodersMu.RUnlock()
+
+	offers := make([]string, len(encoders))
+
+	for i, registered := range encoders {
+		offers[i] = registered.mediaType
+	}
+
+	return offers
+}
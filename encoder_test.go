@@ -0,0 +1,105 @@
+package rest_test
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/edermanoel94/rest-go"
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+type plainEncoder struct{}
+
+func (plainEncoder) Encode(v interface{}) ([]byte, error) {
+	return []byte(fmt.Sprintf("%v", v)), nil
+}
+
+func TestRegisterEncoder(t *testing.T) {
+
+	t.Run("should negotiate a registered encoder", func(t *testing.T) {
+
+		rest.RegisterEncoder("text/plain", plainEncoder{})
+
+		defer rest.UnregisterEncoder("text/plain")
+
+		request := httptest.NewRequest(http.MethodGet, "/", nil)
+		request.Header.Set("Accept", "text/plain")
+
+		recorder := httptest.NewRecorder()
+
+		rest.Negotiate(recorder, request, []int{1, 2}, http.StatusOK)
+
+		assert.Equal(t, http.StatusOK, recorder.Code)
+		assert.Equal(t, "text/plain", recorder.Header().Get("Content-Type"))
+		assert.Equal(t, "[1 2]", recorder.Body.String())
+	})
+
+	t.Run("should route Marshalled and Error through json encoder", func(t *testing.T) {
+
+		calls := 0
+
+		rest.RegisterEncoder("application/json", rest.EncoderFunc(func(v interface{}) ([]byte, error) {
+			calls++
+			return json.Marshal(v)
+		}))
+
+		defer rest.SetMarshaler(nil)
+
+		recorder := httptest.NewRecorder()
+
+		rest.Marshalled(recorder, map[string]int{"id": 1}, http.StatusOK)
+
+		assert.Equal(t, `{"id":1}`, recorder.Body.String())
+
+		recorder = httptest.NewRecorder()
+
+		rest.Error(recorder, errors.New("not found"), http.StatusNotFound)
+
+		assert.Equal(t, `{"message":"not found"}`, recorder.Body.String())
+		assert.Equal(t, 2, calls)
+	})
+
+	t.Run("should produce valid json from messages with special characters", func(t *testing.T) {
+
+		recorder := httptest.NewRecorder()
+
+		rest.Error(recorder, errors.New("line\nwith \\ backslash"), http.StatusBadRequest)
+
+		assert.Equal(t, http.StatusBadRequest, recorder.Code)
+		assert.True(t, json.Valid(recorder.Body.Bytes()))
+		assert.True(t, strings.Contains(recorder.Body.String(), `line\nwith \\ backslash`))
+	})
+}
+
+func TestEncoded(t *testing.T) {
+
+	t.Run("should marshal with encoder of media type", func(t *testing.T) {
+
+		rest.RegisterEncoder("text/plain", plainEncoder{})
+
+		defer rest.UnregisterEncoder("text/plain")
+
+		recorder := httptest.NewRecorder()
+
+		rest.Encoded(recorder, "text/plain", []int{1, 2}, http.StatusOK)
+
+		assert.Equal(t, http.StatusOK, recorder.Code)
+		assert.Equal(t, "text/plain", recorder.Header().Get("Content-Type"))
+		assert.Equal(t, "[1 2]", recorder.Body.String())
+	})
+
+	t.Run("should send a json error when media type have no encoder", func(t *testing.T) {
+
+		recorder := httptest.NewRecorder()
+
+		rest.Encoded(recorder, "application/yaml", []int{1, 2}, http.StatusOK)
+
+		assert.Equal(t, http.StatusInternalServerError, recorder.Code)
+		assert.Equal(t, "application/json", recorder.Header().Get("Content-Type"))
+		assert.Equal(t, `{"message":"no encoder registered for application/yaml"}`, recorder.Body.String())
+	})
+}
@@ -0,0 +1,143 @@
+package rest
+
+import (
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// Enum declare the allowed values of a string-backed type, use it on MarshalJSON and UnmarshalJSON:
+//
+//	var statusEnum = rest.NewEnum("status", "active", "inactive")
+//
+//	func (s Status) MarshalJSON() ([]byte, error) { return statusEnum.Marshal(string(s)) }
+//
+//	func (s *Status) UnmarshalJSON(b []byte) error { return statusEnum.Unmarshal(b, (*string)(s)) }
+type Enum struct {
+	name   string
+	values []string
+	set    map[string]bool
+}
+
+// EnumError is returned when a value don't belong to a enum, rendered as 400 by Error
+type EnumError struct {
+	Enum  string
+	Value string
+	// Allowed are the values accepted by the enum
+	Allowed []string
+}
+
+var (
+	enumsMu sync.RWMutex
+	enums   = make(map[string]*Enum)
+)
+
+// NewEnum declare a enum and register it for documentation, see Enums
+func NewEnum(name string, values ...string) *Enum {
+
+	e := &Enum{
+		name:   name,
+		values: append([]string(nil), values...),
+		set:    make(map[string]bool, len(values)),
+	}
+
+	for _, value := range values {
+		e.set[value] = true
+	}
+
+	enumsMu.Lock()
+	enums[name] = e
+	enumsMu.Unlock()
+
+	return e
+}
+
+// Enums return every declared enum ordered by name, used to document the API
+func Enums() []*Enum {
+
+	enumsMu.RLock()
+	defer enumsMu.RUnlock()
+
+	result := make([]*Enum, 0, len(enums))
+
+	for _, e := range enums {
+		result = append(result, e)
+	}
+
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].name < result[j].name
+	})
+
+	return result
+}
+
+// Name return the name of enum
+func (e *Enum) Name() string {
+	return e.name
+}
+
+// Values return the allowed values on declaration order
+func (e *Enum) Values() []string {
+	return append([]string(nil), e.values...)
+}
+
+// Valid report if value is allowed
+func (e *Enum) Valid(value string) bool {
+	return e.set[value]
+}
+
+// Parse return the value when is allowed, otherwise a *EnumError
+func (e *Enum) Parse(value string) (string, error) {
+	if !e.Valid(value) {
+		return "", &EnumError{Enum: e.name, Value: value, Allowed: e.Values()}
+	}
+	return value, nil
+}
+
+// Marshal encode a allowed value as json string, a invalid value is a error of server
+// and is not reported as *EnumError
+func (e *Enum) Marshal(value string) ([]byte, error) {
+	if !e.Valid(value) {
+		return nil, fmt.Errorf("cannot marshal %s, not a value of %s", value, e.name)
+	}
+	return marshalJson(value)
+}
+
+// Unmarshal decode a json string into value, failing when is not allowed
+func (e *Enum) Unmarshal(data []byte, value *string) error {
+
+	var decoded string
+
+	if err := unmarshalJson(data, &decoded); err != nil {
+		return &EnumError{Enum: e.name, Value: string(data), Allowed: e.Values()}
+	}
+
+	parsed, err := e.Parse(decoded)
+
+	if err != nil {
+		return err
+	}
+
+	*value = parsed
+
+	return nil
+}
+
+// Schema describe the enum as a OpenAPI schema object
+func (e *Enum) Schema() map[string]interface{} {
+	return map[string]interface{}{
+		"type": "string",
+		"enum": e.Values(),
+	}
+}
+
+func (e *EnumError) Error() string {
+	return fmt.Sprintf("invalid value %s for %s, allowed values: %s", e.Value, e.Enum, strings.Join(e.Allowed, ", "))
+}
+
+// StatusCode make Error respond with 400
+func (e *EnumError) StatusCode() int {
+	return http.StatusBadRequest
+}
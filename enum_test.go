@@ -0,0 +1,89 @@
+package rest_test
+
+import (
+	"errors"
+	"github.com/edermanoel94/rest-go"
+	"github.com/stretchr/testify/assert"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+var statusEnum = rest.NewEnum("status", "active", "inactive")
+
+type status string
+
+func (s status) MarshalJSON() ([]byte, error) {
+	return statusEnum.Marshal(string(s))
+}
+
+func (s *status) UnmarshalJSON(b []byte) error {
+	return statusEnum.Unmarshal(b, (*string)(s))
+}
+
+func TestEnum(t *testing.T) {
+
+	t.Run("should marshal and unmarshal a allowed value", func(t *testing.T) {
+
+		account := struct {
+			Status status `json:"status"`
+		}{}
+
+		err := rest.GetBody(ioutil.NopCloser(strings.NewReader(`{"status":"active"}`)), &account)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, status("active"), account.Status)
+
+		recorder := httptest.NewRecorder()
+
+		rest.Marshalled(recorder, &account, http.StatusOK)
+
+		assert.Equal(t, `{"status":"active"}`, recorder.Body.String())
+	})
+
+	t.Run("should respond 400 on invalid inbound value", func(t *testing.T) {
+
+		account := struct {
+			Status status `json:"status"`
+		}{}
+
+		err := rest.GetBody(ioutil.NopCloser(strings.NewReader(`{"status":"deleted"}`)), &account)
+
+		var enumErr *rest.EnumError
+
+		if !errors.As(err, &enumErr) {
+			t.Fatalf("expected a EnumError, got: %v", err)
+		}
+
+		recorder := httptest.NewRecorder()
+
+		rest.Error(recorder, err, http.StatusInternalServerError)
+
+		assert.Equal(t, http.StatusBadRequest, recorder.Code)
+		assert.Contains(t, recorder.Body.String(), "invalid value deleted for status, allowed values: active, inactive")
+	})
+
+	t.Run("should fail to marshal a invalid value", func(t *testing.T) {
+
+		recorder := httptest.NewRecorder()
+
+		rest.Marshalled(recorder, status("deleted"), http.StatusOK)
+
+		assert.Equal(t, http.StatusInternalServerError, recorder.Code)
+	})
+
+	t.Run("should document the enum", func(t *testing.T) {
+
+		assert.Contains(t, rest.Enums(), statusEnum)
+		assert.Equal(t, "status", statusEnum.Name())
+		assert.Equal(t, map[string]interface{}{
+			"type": "string",
+			"enum": []string{"active", "inactive"},
+		}, statusEnum.Schema())
+	})
+}
@@ -0,0 +1,71 @@
+package rest
+
+import (
+	"context"
+	"net/http"
+	"sync"
+)
+
+var (
+	errorCodesMu sync.RWMutex
+	errorCodes   = map[string]int{}
+)
+
+// RegisterErrorCode register a error code with its status and its message by language, the messages
+// are set on DefaultCatalog with the code as key and can use fmt verbs, like "product %s not found"
+func RegisterErrorCode(code string, status int, messages map[string]string) {
+
+	errorCodesMu.Lock()
+	errorCodes[code] = status
+	errorCodesMu.Unlock()
+
+	for lang, text := range messages {
+		DefaultCatalog.Set(lang, code, text)
+	}
+}
+
+// Coder is implemented by errors which carry a code to be sent on code field of body, like
+// CodeError or the errors of other protocols
+type Coder interface {
+	ErrorCode() string
+}
+
+// CodeError is a error of a registered code, ErrorCtx send it translated to language on context,
+// with the code on body, like {"message":"produto tv não encontrado","code":"product_not_found"}
+type CodeError struct {
+	Code string
+	Args []interface{}
+	lang string
+}
+
+// ErrorCode create a error of a code registered by RegisterErrorCode
+func ErrorCode(code string, args ...interface{}) *CodeError {
+	return &CodeError{Code: code, Args: args}
+}
+
+func (e *CodeError) Error() string {
+	return DefaultCatalog.Translate(e.lang, e.Code, e.Args...)
+}
+
+// ErrorCode return the code of error, sent on code field
+func (e *CodeError) ErrorCode() string {
+	return e.Code
+}
+
+// StatusCode return the status registered to code, a unknown code is a error of server
+func (e *CodeError) StatusCode() int {
+
+	errorCodesMu.RLock()
+	defer errorCodesMu.RUnlock()
+
+	if status, ok := errorCodes[e.Code]; ok {
+		return status
+	}
+
+	return http.StatusInternalServerError
+}
+
+// translated return a copy of error which message is on the language of context
+func (e *CodeError) translated(ctx context.Context) *CodeError {
+	return &CodeError{Code: e.Code, Args: e.Args, lang: LanguageFromContext(ctx)}
+}
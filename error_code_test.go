@@ -0,0 +1,67 @@
+package rest_test
+
+import (
+	"fmt"
+	"github.com/edermanoel94/rest-go"
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestErrorCode(t *testing.T) {
+
+	rest.RegisterErrorCode("order_not_found", http.StatusNotFound, map[string]string{
+		"en":    "order %s not found",
+		"pt-BR": "pedido %s não encontrado",
+	})
+
+	handler := rest.Localize("en-US", "pt-BR")(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		rest.ErrorCtx(r.Context(), w, fmt.Errorf("couldn't find: %w", rest.ErrorCode("order_not_found", "42")), 0)
+	}))
+
+	testCases := []struct {
+		description    string
+		acceptLanguage string
+		expected       string
+	}{
+		{"should translate message to language accepted", "pt-BR,pt;q=0.9", `{"message":"pedido 42 não encontrado","code":"order_not_found"}`},
+		{"should use fallback language", "fr", `{"message":"order 42 not found","code":"order_not_found"}`},
+	}
+
+	for _, tc := range testCases {
+
+		t.Run(tc.description, func(t *testing.T) {
+
+			request := httptest.NewRequest(http.MethodGet, "/orders/42", nil)
+			request.Header.Set("Accept-Language", tc.acceptLanguage)
+
+			recorder := httptest.NewRecorder()
+
+			handler.ServeHTTP(recorder, request)
+
+			assert.Equal(t, http.StatusNotFound, recorder.Code)
+			assert.Equal(t, tc.expected, recorder.Body.String())
+		})
+	}
+
+	t.Run("should send message of fallback language without context", func(t *testing.T) {
+
+		recorder := httptest.NewRecorder()
+
+		rest.Error(recorder, rest.ErrorCode("order_not_found", "7"), http.StatusBadRequest)
+
+		assert.Equal(t, http.StatusNotFound, recorder.Code)
+		assert.Equal(t, `{"message":"order 7 not found","code":"order_not_found"}`, recorder.Body.String())
+	})
+
+	t.Run("should send internal server error to unknown code", func(t *testing.T) {
+
+		recorder := httptest.NewRecorder()
+
+		rest.Error(recorder, rest.ErrorCode("unknown_code"), 0)
+
+		assert.Equal(t, http.StatusInternalServerError, recorder.Code)
+		assert.Equal(t, `{"message":"unknown_code","code":"unknown_code"}`, recorder.Body.String())
+	})
+}
@@ -0,0 +1,51 @@
+package rest
+
+import (
+	"errors"
+	"strings"
+)
+
+// Errors is a error with many errors, Error send the message of each one on errors field, like
+// {"message":"name is required; price must be positive","errors":[{"message":"name is required"},...]}.
+// A error created by errors.Join is sent the same way.
+type Errors []error
+
+func (e Errors) Error() string {
+
+	messages := make([]string, len(e))
+
+	for i, err := range e {
+		messages[i] = err.Error()
+	}
+
+	return strings.Join(messages, "; ")
+}
+
+// Unwrap return the errors, so errors.Is and errors.As look at each one
+func (e Errors) Unwrap() []error {
+	return e
+}
+
+// Err return nil when there is no error, so a Errors can be filled and returned as a error
+func (e Errors) Err() error {
+	if len(e) == 0 {
+		return nil
+	}
+	return e
+}
+
+type multiError interface {
+	Unwrap() []error
+}
+
+// multiErrors return the errors of the first multi error on chain of err
+func multiErrors(err error) ([]error, bool) {
+
+	var multi multiError
+
+	if errors.As(err, &multi) {
+		return multi.Unwrap(), true
+	}
+
+	return nil, false
+}
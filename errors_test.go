@@ -0,0 +1,79 @@
+package rest_test
+
+import (
+	"errors"
+	"fmt"
+	"github.com/edermanoel94/rest-go"
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+// joinError is like the error of errors.Join
+type joinError struct {
+	errs []error
+}
+
+func (j *joinError) Error() string {
+	return "many errors"
+}
+
+func (j *joinError) Unwrap() []error {
+	return j.errs
+}
+
+func TestErrors(t *testing.T) {
+
+	t.Run("should send each error on errors", func(t *testing.T) {
+
+		errs := rest.Errors{errors.New("name is required"), errors.New("price must be positive")}
+
+		recorder := httptest.NewRecorder()
+
+		rest.Error(recorder, errs, http.StatusBadRequest)
+
+		assert.Equal(t, http.StatusBadRequest, recorder.Code)
+		assert.Equal(t, `{"message":"name is required; price must be positive",`+
+			`"errors":[{"message":"name is required"},{"message":"price must be positive"}]}`, recorder.Body.String())
+	})
+
+	t.Run("should send errors of a joined error", func(t *testing.T) {
+
+		err := fmt.Errorf("couldn't import: %w", &joinError{errs: []error{
+			rest.ErrorCode("line_invalid"),
+			&rest.ValidationError{Fields: []rest.FieldError{{Name: "name", Rule: "required", Message: "name is required"}}},
+		}})
+
+		recorder := httptest.NewRecorder()
+
+		rest.Error(recorder, err, http.StatusBadRequest)
+
+		assert.Equal(t, `{"message":"couldn't import: many errors","errors":[`+
+			`{"message":"line_invalid","code":"line_invalid"},`+
+			`{"message":"validation failed: name is required","fields":[{"name":"name","rule":"required","message":"name is required"}]}]}`,
+			recorder.Body.String())
+	})
+
+	t.Run("should send status of first StatusCoder", func(t *testing.T) {
+
+		errs := rest.Errors{errors.New("name is required"), &forbiddenError{reason: "forbidden"}}
+
+		recorder := httptest.NewRecorder()
+
+		rest.Error(recorder, errs, 0)
+
+		assert.Equal(t, http.StatusForbidden, recorder.Code)
+	})
+
+	t.Run("should return nil without errors", func(t *testing.T) {
+
+		var errs rest.Errors
+
+		assert.NoError(t, errs.Err())
+
+		errs = append(errs, errors.New("name is required"))
+
+		assert.Error(t, errs.Err())
+	})
+}
@@ -0,0 +1,70 @@
+package rest
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"net/http"
+	"strings"
+)
+
+// ETag return a strong entity tag of body, a quoted hash of content
+func ETag(body []byte) string {
+	sum := sha256.Sum256(body)
+	return `"` + hex.EncodeToString(sum[:16]) + `"`
+}
+
+// ContentConditional send slice of bytes to respond json like Response, with a ETag header of the
+// body sent, after indent, transformers and interceptors.
+// On GET and HEAD, when the If-None-Match header match the ETag, 304 is sent without body.
+func ContentConditional(w http.ResponseWriter, r *http.Request, body []byte, code int, opts ...Option) (int, error) {
+
+	c := newCall(r.Context(), opts...)
+
+	c.last = conditional(r)
+
+	return c.content(w, body, code)
+}
+
+// conditional return the last WriterFunc of ContentConditional, which hash the final body
+func conditional(r *http.Request) WriterFunc {
+	return func(w http.ResponseWriter, reply *Reply) (int, error) {
+
+		if reply.Err != nil {
+			return writeReply(w, reply)
+		}
+
+		etag := ETag(reply.Body)
+
+		w.Header().Set("ETag", etag)
+
+		if (r.Method == http.MethodGet || r.Method == http.MethodHead) && matchETag(r.Header.Get("If-None-Match"), etag) {
+			reply.Status, reply.ContentType = http.StatusNotModified, ""
+			w.WriteHeader(http.StatusNotModified)
+			return 0, nil
+		}
+
+		return writeReply(w, reply)
+	}
+}
+
+// matchETag report if If-None-Match header match etag, using weak comparison
+func matchETag(header, etag string) bool {
+
+	header = strings.TrimSpace(header)
+
+	if header == "" {
+		return false
+	}
+
+	if header == "*" {
+		return true
+	}
+
+	for _, candidate := range strings.Split(header, ",") {
+		if strings.TrimPrefix(strings.TrimSpace(candidate), "W/") == strings.TrimPrefix(etag, "W/") {
+			return true
+		}
+	}
+
+	return false
+}
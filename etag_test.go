@@ -0,0 +1,80 @@
+package rest_test
+
+import (
+	"github.com/edermanoel94/rest-go"
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestContentConditional(t *testing.T) {
+
+	payload := []byte(`{"name":"tv"}`)
+
+	etag := rest.ETag(payload)
+
+	testCases := []struct {
+		description string
+		method      string
+		ifNoneMatch string
+		statusCode  int
+		body        string
+	}{
+		{"should send body and etag without If-None-Match", http.MethodGet, "", http.StatusOK, `{"name":"tv"}`},
+		{"should send 304 when etag match", http.MethodGet, etag, http.StatusNotModified, ""},
+		{"should send 304 when one of etags match", http.MethodGet, `"other", W/` + etag, http.StatusNotModified, ""},
+		{"should send 304 on wildcard", http.MethodGet, "*", http.StatusNotModified, ""},
+		{"should send body when etag don't match", http.MethodGet, `"other"`, http.StatusOK, `{"name":"tv"}`},
+		{"should send body on methods which are not GET or HEAD", http.MethodPut, etag, http.StatusOK, `{"name":"tv"}`},
+	}
+
+	for _, tc := range testCases {
+
+		t.Run(tc.description, func(t *testing.T) {
+
+			request := httptest.NewRequest(tc.method, "/", nil)
+			request.Header.Set("If-None-Match", tc.ifNoneMatch)
+
+			recorder := httptest.NewRecorder()
+
+			rest.ContentConditional(recorder, request, payload, http.StatusOK)
+
+			assert.Equal(t, tc.statusCode, recorder.Code)
+			assert.Equal(t, etag, recorder.Header().Get("ETag"))
+			assert.Equal(t, tc.body, recorder.Body.String())
+		})
+	}
+
+	t.Run("should hash the body sent", func(t *testing.T) {
+
+		rest.Use(func(next rest.WriterFunc) rest.WriterFunc {
+			return func(w http.ResponseWriter, reply *rest.Reply) (int, error) {
+				reply.Body = append(append([]byte(`{"data":`), reply.Body...), '}')
+				return next(w, reply)
+			}
+		})
+		defer rest.ResetInterceptors()
+
+		recorder := httptest.NewRecorder()
+
+		rest.ContentConditional(recorder, httptest.NewRequest(http.MethodGet, "/", nil), payload, http.StatusOK, rest.Indent("  "))
+
+		assert.Equal(t, rest.ETag(recorder.Body.Bytes()), recorder.Header().Get("ETag"))
+
+		request := httptest.NewRequest(http.MethodGet, "/", nil)
+		request.Header.Set("If-None-Match", recorder.Header().Get("ETag"))
+
+		recorder = httptest.NewRecorder()
+
+		rest.ContentConditional(recorder, request, payload, http.StatusOK, rest.Indent("  "))
+
+		assert.Equal(t, http.StatusNotModified, recorder.Code)
+		assert.Empty(t, recorder.Body.String())
+	})
+
+	t.Run("should create different etags for different bodies", func(t *testing.T) {
+		assert.NotEqual(t, rest.ETag([]byte(`{"name":"radio"}`)), etag)
+		assert.Len(t, etag, 34)
+	})
+}
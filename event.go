@@ -0,0 +1,90 @@
+package rest
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// Event describe a response sent by a write helper
+type Event struct {
+	// Context is the context given to helper, or context.Background
+	Context  context.Context
+	Status   int
+	Bytes    int
+	Duration time.Duration
+	// EncodeDuration is the time spent encoding the body before writing it, 0 when the
+	// body is written while encoded, like streams and files
+	EncodeDuration time.Duration
+	ContentType    string
+	// Err is the error sent by Error, nil on success
+	Err error
+	// WriteErr is the error returned by the writer
+	WriteErr error
+}
+
+var (
+	writeHooksMu    sync.RWMutex
+	writeHooks      []func(Event)
+	writeErrorHooks []func(error)
+)
+
+// OnWrite add a hook called after every response sent by the package, like a logger.
+// Hooks are called on the goroutine of handler and must be fast.
+func OnWrite(hook func(Event)) {
+	writeHooksMu.Lock()
+	defer writeHooksMu.Unlock()
+	writeHooks = append(writeHooks, hook)
+}
+
+// OnWriteError add a hook called when the writer fail to send a body, like a broken pipe
+// or a short write, which are returned by helpers too
+func OnWriteError(hook func(error)) {
+	writeHooksMu.Lock()
+	defer writeHooksMu.Unlock()
+	writeErrorHooks = append(writeErrorHooks, hook)
+}
+
+// ResetWriteHooks remove every hook added by OnWrite and OnWriteError
+func ResetWriteHooks() {
+	writeHooksMu.Lock()
+	defer writeHooksMu.Unlock()
+	writeHooks = nil
+	writeErrorHooks = nil
+}
+
+// notify call the write hooks with the event of call
+func (c *call) notify(contentType string, status, bytes int, writeErr error) {
+
+	writeHooksMu.RLock()
+	hooks, errorHooks := writeHooks, writeErrorHooks
+	writeHooksMu.RUnlock()
+
+	if writeErr != nil {
+		for _, hook := range errorHooks {
+			hook(writeErr)
+		}
+	}
+
+	if len(hooks) == 0 {
+		return
+	}
+
+	event := Event{
+		Context:     c.ctx,
+		Status:      status,
+		Bytes:       bytes,
+		Duration:    time.Since(c.start),
+		ContentType: contentType,
+		Err:         c.err,
+		WriteErr:    writeErr,
+	}
+
+	if !c.encoded.IsZero() {
+		event.EncodeDuration = c.encoded.Sub(c.start)
+	}
+
+	for _, hook := range hooks {
+		hook(event)
+	}
+}
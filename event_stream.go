@@ -0,0 +1,188 @@
+package rest
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+)
+
+const textEventStream = "text/event-stream"
+
+// DefaultHeartbeat is the interval of heartbeats sent by EventStream
+var DefaultHeartbeat = 15 * time.Second
+
+// EventStreamer send Server-Sent Events, it stops when the request context is done or on Close.
+// The heartbeats are written by other goroutine, so the handler must defer Close: the server
+// can cancel the request context after ServeHTTP return, when the writer is not valid anymore.
+//
+//	stream := rest.EventStream(w, r)
+//	defer stream.Close()
+type EventStreamer struct {
+	mu      sync.Mutex
+	w       http.ResponseWriter
+	flusher http.Flusher
+	ctx     context.Context
+	done    chan struct{}
+	closed  bool
+	err     error
+}
+
+// EventStream start a text/event-stream response, sending heartbeats with DefaultHeartbeat interval,
+// the handler must defer Close
+func EventStream(w http.ResponseWriter, r *http.Request) *EventStreamer {
+	return EventStreamHeartbeat(w, r, DefaultHeartbeat)
+}
+
+// EventStreamHeartbeat start a text/event-stream response, sending heartbeats on interval to keep
+// the connection alive through proxies, a interval of zero disable heartbeats. The handler must
+// defer Close, so no heartbeat is written after it return
+func EventStreamHeartbeat(w http.ResponseWriter, r *http.Request, interval time.Duration) *EventStreamer {
+
+	flusher, _ := w.(http.Flusher)
+
+	e := &EventStreamer{w: w, flusher: flusher, ctx: r.Context(), done: make(chan struct{})}
+
+	applyDefaultHeaders(w.Header())
+	w.Header().Set(contentType, textEventStream)
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("Connection", "keep-alive")
+	w.Header().Set("X-Accel-Buffering", "no")
+	w.WriteHeader(http.StatusOK)
+
+	e.flush()
+
+	go e.heartbeat(interval)
+
+	return e
+}
+
+// SendEvent send a event with data encoded as json, a empty name send a message event
+func (e *EventStreamer) SendEvent(name string, data interface{}) error {
+
+	bytes, err := encode(applicationJson, data)
+
+	if err != nil {
+		return err
+	}
+
+	var event strings.Builder
+
+	if name != "" {
+		event.WriteString("event: ")
+		event.WriteString(sanitizeEventField(name))
+		event.WriteString("\n")
+	}
+
+	for _, line := range strings.Split(string(bytes), "\n") {
+		event.WriteString("data: ")
+		event.WriteString(line)
+		event.WriteString("\n")
+	}
+
+	event.WriteString("\n")
+
+	return e.send(event.String())
+}
+
+// Done is closed when the stream stop
+func (e *EventStreamer) Done() <-chan struct{} {
+	return e.done
+}
+
+// Close stop the stream and the heartbeats, nothing is written after it return. It must be
+// deferred by the handler which started the stream
+func (e *EventStreamer) Close() {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.close()
+}
+
+// close must be called with mu held
+func (e *EventStreamer) close() {
+	if !e.closed {
+		e.closed = true
+		close(e.done)
+	}
+}
+
+func (e *EventStreamer) send(event string) error {
+
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	if err := e.stopped(); err != nil {
+		return err
+	}
+
+	if e.err != nil {
+		return e.err
+	}
+
+	if _, err := e.w.Write([]byte(event)); err != nil {
+		e.err = err
+		e.close()
+		return err
+	}
+
+	e.flush()
+
+	return nil
+}
+
+// stopped return a error when the stream is closed or the request context is done, it must be
+// called with mu held
+func (e *EventStreamer) stopped() error {
+
+	if e.closed {
+		return ErrStreamClosed
+	}
+
+	if err := e.ctx.Err(); err != nil {
+		e.close()
+		return err
+	}
+
+	return nil
+}
+
+func (e *EventStreamer) heartbeat(interval time.Duration) {
+
+	if interval <= 0 {
+		select {
+		case <-e.ctx.Done():
+			e.Close()
+		case <-e.done:
+		}
+		return
+	}
+
+	ticker := time.NewTicker(interval)
+
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-e.ctx.Done():
+			e.Close()
+			return
+		case <-e.done:
+			return
+		case <-ticker.C:
+			if e.send(": heartbeat\n\n") != nil {
+				return
+			}
+		}
+	}
+}
+
+func (e *EventStreamer) flush() {
+	if e.flusher != nil {
+		e.flusher.Flush()
+	}
+}
+
+func sanitizeEventField(value string) string {
+	return strings.NewReplacer("\r", "", "\n", "").Replace(value)
+}
@@ -0,0 +1,122 @@
+package rest_test
+
+import (
+	"context"
+	"github.com/edermanoel94/rest-go"
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+// syncRecorder is a recorder safe to be written by other goroutines
+type syncRecorder struct {
+	mu     sync.Mutex
+	header http.Header
+	code   int
+	body   strings.Builder
+}
+
+func newSyncRecorder() *syncRecorder {
+	return &syncRecorder{header: make(http.Header)}
+}
+
+func (s *syncRecorder) Header() http.Header {
+	return s.header
+}
+
+func (s *syncRecorder) WriteHeader(code int) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.code = code
+}
+
+func (s *syncRecorder) Write(b []byte) (int, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.body.Write(b)
+}
+
+func (s *syncRecorder) Flush() {}
+
+func (s *syncRecorder) String() string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.body.String()
+}
+
+func TestEventStream(t *testing.T) {
+
+	t.Run("should send events with json data", func(t *testing.T) {
+
+		recorder := newSyncRecorder()
+
+		stream := rest.EventStreamHeartbeat(recorder, httptest.NewRequest(http.MethodGet, "/", nil), 0)
+
+		defer stream.Close()
+
+		assert.NoError(t, stream.SendEvent("progress", map[string]int{"done": 42}))
+		assert.NoError(t, stream.SendEvent("", "hello"))
+
+		assert.Equal(t, http.StatusOK, recorder.code)
+		assert.Equal(t, "text/event-stream", recorder.Header().Get("Content-Type"))
+		assert.Equal(t, "no-cache", recorder.Header().Get("Cache-Control"))
+		assert.Equal(t, "event: progress\ndata: {\"done\":42}\n\ndata: \"hello\"\n\n", recorder.String())
+	})
+
+	t.Run("should send heartbeats", func(t *testing.T) {
+
+		recorder := newSyncRecorder()
+
+		stream := rest.EventStreamHeartbeat(recorder, httptest.NewRequest(http.MethodGet, "/", nil), time.Millisecond)
+
+		assert.Eventually(t, func() bool {
+			return strings.Contains(recorder.String(), ": heartbeat\n\n")
+		}, time.Second, time.Millisecond)
+
+		stream.Close()
+
+		assert.Equal(t, rest.ErrStreamClosed, stream.SendEvent("late", 1))
+	})
+
+	t.Run("should not send heartbeats after close", func(t *testing.T) {
+
+		recorder := newSyncRecorder()
+
+		stream := rest.EventStreamHeartbeat(recorder, httptest.NewRequest(http.MethodGet, "/", nil), time.Microsecond)
+
+		assert.Eventually(t, func() bool {
+			return strings.Contains(recorder.String(), ": heartbeat\n\n")
+		}, time.Second, time.Millisecond)
+
+		stream.Close()
+
+		body := recorder.String()
+
+		time.Sleep(10 * time.Millisecond)
+
+		assert.Equal(t, body, recorder.String())
+	})
+
+	t.Run("should stop when request context is cancelled", func(t *testing.T) {
+
+		ctx, cancel := context.WithCancel(context.Background())
+
+		request := httptest.NewRequest(http.MethodGet, "/", nil).WithContext(ctx)
+
+		stream := rest.EventStream(newSyncRecorder(), request)
+
+		cancel()
+
+		select {
+		case <-stream.Done():
+		case <-time.After(time.Second):
+			t.Fatal("expected stream to stop")
+		}
+
+		assert.Error(t, stream.SendEvent("late", 1))
+	})
+}
@@ -0,0 +1,141 @@
+package rest_test
+
+import (
+	"context"
+	"errors"
+	"github.com/edermanoel94/rest-go"
+	"github.com/stretchr/testify/assert"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+type brokenWriter struct {
+	*httptest.ResponseRecorder
+}
+
+func (brokenWriter) Write([]byte) (int, error) {
+	return 0, errors.New("broken pipe")
+}
+
+func TestOnWrite(t *testing.T) {
+
+	events := make([]rest.Event, 0)
+
+	rest.OnWrite(func(event rest.Event) {
+		events = append(events, event)
+	})
+
+	defer rest.ResetWriteHooks()
+
+	t.Run("should notify a success", func(t *testing.T) {
+
+		events = events[:0]
+
+		rest.Marshalled(httptest.NewRecorder(), map[string]int{"id": 1}, http.StatusCreated)
+
+		if len(events) != 1 {
+			t.Fatalf("expected one event, got: %d", len(events))
+		}
+
+		assert.Equal(t, http.StatusCreated, events[0].Status)
+		assert.Equal(t, 8, events[0].Bytes)
+		assert.Equal(t, "application/json", events[0].ContentType)
+		assert.NoError(t, events[0].Err)
+		assert.NoError(t, events[0].WriteErr)
+		assert.True(t, events[0].Duration > 0)
+		assert.True(t, events[0].EncodeDuration > 0 && events[0].EncodeDuration <= events[0].Duration)
+	})
+
+	t.Run("should notify the error sent", func(t *testing.T) {
+
+		events = events[:0]
+
+		err := errors.New("not found")
+
+		ctx := rest.WithLanguage(context.Background(), "en")
+
+		rest.ErrorCtx(ctx, httptest.NewRecorder(), err, http.StatusNotFound)
+
+		assert.Equal(t, http.StatusNotFound, events[0].Status)
+		assert.Equal(t, err, events[0].Err)
+		assert.Equal(t, "en", rest.LanguageFromContext(events[0].Context))
+	})
+
+	t.Run("should notify the error of writer", func(t *testing.T) {
+
+		events = events[:0]
+
+		rest.Response(brokenWriter{httptest.NewRecorder()}, []byte(`{}`), http.StatusOK)
+
+		assert.EqualError(t, events[0].WriteErr, "broken pipe")
+		assert.Equal(t, 0, events[0].Bytes)
+	})
+}
+
+type shortWriter struct {
+	*httptest.ResponseRecorder
+}
+
+func (shortWriter) Write(b []byte) (int, error) {
+	return len(b) / 2, nil
+}
+
+func TestOnWriteError(t *testing.T) {
+
+	errs := make([]error, 0)
+
+	rest.OnWriteError(func(err error) {
+		errs = append(errs, err)
+	})
+
+	defer rest.ResetWriteHooks()
+
+	t.Run("should return and notify a broken pipe", func(t *testing.T) {
+
+		errs = errs[:0]
+
+		n, err := rest.Marshalled(brokenWriter{httptest.NewRecorder()}, []int{1}, http.StatusOK)
+
+		assert.Equal(t, 0, n)
+		assert.EqualError(t, err, "broken pipe")
+		assert.Len(t, errs, 1)
+	})
+
+	t.Run("should return and notify a short write", func(t *testing.T) {
+
+		errs = errs[:0]
+
+		n, err := rest.Response(shortWriter{httptest.NewRecorder()}, []byte(`{"id":1}`), http.StatusOK)
+
+		assert.Equal(t, 4, n)
+		assert.Equal(t, io.ErrShortWrite, err)
+		assert.Equal(t, []error{io.ErrShortWrite}, errs)
+	})
+
+	t.Run("should return bytes written of error", func(t *testing.T) {
+
+		errs = errs[:0]
+
+		recorder := httptest.NewRecorder()
+
+		n, err := rest.Error(recorder, errors.New("not found"), http.StatusNotFound)
+
+		assert.NoError(t, err)
+		assert.Equal(t, recorder.Body.Len(), n)
+		assert.Empty(t, errs)
+	})
+
+	t.Run("should notify a stream which failed", func(t *testing.T) {
+
+		errs = errs[:0]
+
+		stream := rest.Stream(brokenWriter{httptest.NewRecorder()})
+
+		stream.Send(1)
+		stream.Close()
+
+		assert.Len(t, errs, 1)
+	})
+}
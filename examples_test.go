@@ -34,7 +34,7 @@ func ExampleMarshalled() {
 	// Output: {"name":"Smart TV","price":150.2}
 }
 
-func ExampleContent() {
+func ExampleResponse() {
 
 	product := struct {
 		Name  string  `json:"name"`
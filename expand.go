@@ -0,0 +1,289 @@
+package rest
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"reflect"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// Loader load a relation for many resources at once, avoiding N+1 queries.
+// It must return the related value of each parent at the same index, a slice
+// is rendered as a list and nil as null.
+type Loader func(ctx context.Context, parents []interface{}) ([]interface{}, error)
+
+// Expander embed relations requested by query parameter expand, like ?expand=author,comments.author
+type Expander struct {
+	mu       sync.RWMutex
+	maxDepth int
+	loaders  map[string]Loader
+}
+
+// ExpandError is returned when expand parameter is invalid, rendered as 400 by Error
+type ExpandError struct {
+	Message string
+}
+
+func (e *ExpandError) Error() string {
+	return e.Message
+}
+
+// StatusCode make Error respond with 400
+func (e *ExpandError) StatusCode() int {
+	return http.StatusBadRequest
+}
+
+// DefaultExpander is used by MarshalledExpanded and RegisterExpansion
+var DefaultExpander = NewExpander(3)
+
+const expandParam = "expand"
+
+// NewExpander create a expander which accept relations nested until maxDepth, like 2 for comments.author
+func NewExpander(maxDepth int) *Expander {
+	return &Expander{maxDepth: maxDepth, loaders: make(map[string]Loader)}
+}
+
+// Register add a loader for relation name, used on every depth which request it
+func (e *Expander) Register(name string, loader Loader) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.loaders[name] = loader
+}
+
+// RegisterExpansion add a loader for relation name on DefaultExpander
+func RegisterExpansion(name string, loader Loader) {
+	DefaultExpander.Register(name, loader)
+}
+
+// MarshalledExpanded marshall and respond json embedding the relations requested on DefaultExpander
+func MarshalledExpanded(w http.ResponseWriter, r *http.Request, v interface{}, code int) (int, error) {
+	return DefaultExpander.Marshalled(w, r, v, code)
+}
+
+// Marshalled marshall and respond json embedding the relations requested, v can be a resource or a slice of resources.
+// The loaders receive the elements of slice, or the resource itself, without the pointer given on v.
+func (e *Expander) Marshalled(w http.ResponseWriter, r *http.Request, v interface{}, code int) (int, error) {
+
+	ctx := r.Context()
+
+	c := newCall(ctx)
+
+	tree, err := e.parse(r.URL.Query()[expandParam])
+
+	if err != nil {
+		return c.fail(w, err, http.StatusBadRequest)
+	}
+
+	value := reflect.ValueOf(v)
+
+	for value.Kind() == reflect.Ptr && !value.IsNil() {
+		value = value.Elem()
+	}
+
+	if value.Kind() != reflect.Slice || value.Type().Elem().Kind() == reflect.Uint8 {
+
+		if !value.IsValid() {
+			return c.marshalled(w, nil, code)
+		}
+
+		expanded, err := e.expand(ctx, []interface{}{value.Interface()}, tree)
+
+		if err != nil {
+			return c.fail(w, err, http.StatusInternalServerError)
+		}
+
+		return c.marshalled(w, expanded[0], code)
+	}
+
+	if value.IsNil() {
+		return c.marshalled(w, nil, code)
+	}
+
+	values := make([]interface{}, value.Len())
+
+	for i := range values {
+		values[i] = value.Index(i).Interface()
+	}
+
+	expanded, err := e.expand(ctx, values, tree)
+
+	if err != nil {
+		return c.fail(w, err, http.StatusInternalServerError)
+	}
+
+	return c.marshalled(w, expanded, code)
+}
+
+// expandTree is the relations requested, by name
+type expandTree map[string]expandTree
+
+func (e *Expander) parse(params []string) (expandTree, error) {
+
+	e.mu.RLock()
+	defer e.mu.RUnlock()
+
+	tree := make(expandTree)
+
+	for _, param := range params {
+
+		for _, path := range strings.Split(param, ",") {
+
+			path = strings.TrimSpace(path)
+
+			if path == "" {
+				continue
+			}
+
+			names := strings.Split(path, ".")
+
+			if len(names) > e.maxDepth {
+				return nil, &ExpandError{Message: fmt.Sprintf("expand %s is deeper than %d", path, e.maxDepth)}
+			}
+
+			node := tree
+
+			for _, name := range names {
+
+				if _, ok := e.loaders[name]; !ok {
+					return nil, &ExpandError{Message: fmt.Sprintf("cannot expand %s, unknown relation %s", path, name)}
+				}
+
+				if _, ok := node[name]; !ok {
+					node[name] = make(expandTree)
+				}
+
+				node = node[name]
+			}
+		}
+	}
+
+	return tree, nil
+}
+
+// expand shape values and embed the relations of tree, loading each relation once by depth
+func (e *Expander) expand(ctx context.Context, values []interface{}, tree expandTree) ([]interface{}, error) {
+
+	s := &shaper{}
+
+	shaped := make([]interface{}, len(values))
+
+	for i, value := range values {
+		shaped[i] = s.shape(reflect.ValueOf(value))
+	}
+
+	if len(values) == 0 {
+		return shaped, nil
+	}
+
+	names := make([]string, 0, len(tree))
+
+	for name := range tree {
+		names = append(names, name)
+	}
+
+	sort.Strings(names)
+
+	for _, name := range names {
+
+		e.mu.RLock()
+		loader := e.loaders[name]
+		e.mu.RUnlock()
+
+		related, err := loader(ctx, values)
+
+		if err != nil {
+			return nil, fmt.Errorf("couldn't load %s: %w", name, err)
+		}
+
+		if len(related) != len(values) {
+			return nil, fmt.Errorf("loader of %s returned %d values for %d parents", name, len(related), len(values))
+		}
+
+		children, assemble := flattenRelated(related)
+
+		expandedChildren, err := e.expand(ctx, children, tree[name])
+
+		if err != nil {
+			return nil, err
+		}
+
+		for i, value := range assemble(expandedChildren) {
+			if obj, ok := shaped[i].(object); ok {
+				shaped[i] = obj.set(name, value)
+			}
+		}
+	}
+
+	return shaped, nil
+}
+
+// flattenRelated return the related resources on a single slice, so the next depth is loaded
+// at once, and a function which rebuild the related of each parent from the expanded children
+func flattenRelated(related []interface{}) ([]interface{}, func([]interface{}) []interface{}) {
+
+	children := make([]interface{}, 0, len(related))
+
+	// bounds of children which belong to each parent, a negative start is a nil relation
+	type bounds struct {
+		start, end int
+		list       bool
+	}
+
+	positions := make([]bounds, len(related))
+
+	for i, rel := range related {
+
+		value := reflect.ValueOf(rel)
+
+		if !value.IsValid() || ((value.Kind() == reflect.Ptr || value.Kind() == reflect.Slice) && value.IsNil()) {
+			positions[i] = bounds{start: -1}
+			continue
+		}
+
+		if (value.Kind() == reflect.Slice || value.Kind() == reflect.Array) && value.Type().Elem().Kind() != reflect.Uint8 {
+			start := len(children)
+			for j := 0; j < value.Len(); j++ {
+				children = append(children, value.Index(j).Interface())
+			}
+			positions[i] = bounds{start: start, end: len(children), list: true}
+			continue
+		}
+
+		positions[i] = bounds{start: len(children), end: len(children) + 1}
+		children = append(children, rel)
+	}
+
+	return children, func(expanded []interface{}) []interface{} {
+
+		result := make([]interface{}, len(positions))
+
+		for i, position := range positions {
+			switch {
+			case position.start < 0:
+				result[i] = nil
+			case position.list:
+				result[i] = expanded[position.start:position.end]
+			default:
+				result[i] = expanded[position.start]
+			}
+		}
+
+		return result
+	}
+}
+
+// set return the object with field name replaced or appended
+func (o object) set(name string, value interface{}) object {
+
+	for i, field := range o {
+		if field.name == name {
+			o[i].value = value
+			return o
+		}
+	}
+
+	return append(o, objectField{name: name, value: value})
+}
@@ -0,0 +1,124 @@
+package rest_test
+
+import (
+	"context"
+	"errors"
+	"github.com/edermanoel94/rest-go"
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+type post struct {
+	ID       int `json:"id"`
+	AuthorID int `json:"author_id"`
+}
+
+type comment struct {
+	ID       int    `json:"id"`
+	Text     string `json:"text"`
+	AuthorID int    `json:"author_id"`
+}
+
+type user struct {
+	ID   int    `json:"id"`
+	Name string `json:"name"`
+}
+
+func newExpander(calls map[string]int) *rest.Expander {
+
+	users := map[int]user{1: {1, "eder"}, 2: {2, "costa"}}
+
+	expander := rest.NewExpander(2)
+
+	expander.Register("author", func(ctx context.Context, parents []interface{}) ([]interface{}, error) {
+		calls["author"]++
+		related := make([]interface{}, len(parents))
+		for i, parent := range parents {
+			switch p := parent.(type) {
+			case post:
+				related[i] = users[p.AuthorID]
+			case comment:
+				related[i] = users[p.AuthorID]
+			}
+		}
+		return related, nil
+	})
+
+	expander.Register("comments", func(ctx context.Context, parents []interface{}) ([]interface{}, error) {
+		calls["comments"]++
+		related := make([]interface{}, len(parents))
+		for i, parent := range parents {
+			if parent.(post).ID == 1 {
+				related[i] = []comment{{1, "nice", 2}, {2, "thanks", 1}}
+			}
+		}
+		return related, nil
+	})
+
+	expander.Register("broken", func(ctx context.Context, parents []interface{}) ([]interface{}, error) {
+		return nil, errors.New("database is down")
+	})
+
+	return expander
+}
+
+func TestExpander(t *testing.T) {
+
+	posts := []post{{1, 1}, {2, 2}}
+
+	testCases := []struct {
+		description string
+		url         string
+		statusCode  int
+		expected    string
+		calls       map[string]int
+	}{
+		{"should not expand without parameter", "/posts", http.StatusOK,
+			`[{"id":1,"author_id":1},{"id":2,"author_id":2}]`, map[string]int{}},
+		{"should expand relations loading each once", "/posts?expand=author,comments", http.StatusOK,
+			`[{"id":1,"author_id":1,"author":{"id":1,"name":"eder"},"comments":[{"id":1,"text":"nice","author_id":2},{"id":2,"text":"thanks","author_id":1}]},` +
+				`{"id":2,"author_id":2,"author":{"id":2,"name":"costa"},"comments":null}]`,
+			map[string]int{"author": 1, "comments": 1}},
+		{"should expand nested relations", "/posts?expand=comments.author", http.StatusOK,
+			`[{"id":1,"author_id":1,"comments":[{"id":1,"text":"nice","author_id":2,"author":{"id":2,"name":"costa"}},` +
+				`{"id":2,"text":"thanks","author_id":1,"author":{"id":1,"name":"eder"}}]},{"id":2,"author_id":2,"comments":null}]`,
+			map[string]int{"author": 1, "comments": 1}},
+		{"should reject relation deeper than limit", "/posts?expand=comments.author.author", http.StatusBadRequest,
+			`{"message":"expand comments.author.author is deeper than 2"}`, map[string]int{}},
+		{"should reject unknown relation", "/posts?expand=likes", http.StatusBadRequest,
+			`{"message":"cannot expand likes, unknown relation likes"}`, map[string]int{}},
+		{"should respond 500 when loader fail", "/posts?expand=broken", http.StatusInternalServerError,
+			`{"message":"couldn't load broken: database is down"}`, map[string]int{}},
+	}
+
+	for _, tc := range testCases {
+
+		t.Run(tc.description, func(t *testing.T) {
+
+			calls := make(map[string]int)
+
+			recorder := httptest.NewRecorder()
+
+			request := httptest.NewRequest(http.MethodGet, tc.url, nil)
+
+			newExpander(calls).Marshalled(recorder, request, &posts, http.StatusOK)
+
+			assert.Equal(t, tc.statusCode, recorder.Code)
+			assert.Equal(t, tc.expected, recorder.Body.String())
+			assert.Equal(t, tc.calls, calls)
+		})
+	}
+
+	t.Run("should expand a single resource", func(t *testing.T) {
+
+		recorder := httptest.NewRecorder()
+
+		request := httptest.NewRequest(http.MethodGet, "/posts/2?expand=author", nil)
+
+		newExpander(map[string]int{}).Marshalled(recorder, request, &posts[1], http.StatusOK)
+
+		assert.Equal(t, `{"id":2,"author_id":2,"author":{"id":2,"name":"costa"}}`, recorder.Body.String())
+	})
+}
@@ -0,0 +1,114 @@
+package rest
+
+import (
+	"net/http"
+	"reflect"
+	"strings"
+)
+
+const fieldsParam = "fields"
+
+// Filtered marshall and respond json like Marshalled, keeping only the fields requested by query
+// parameter fields, like ?fields=name,owner.id. Nested paths filter the objects inside of a field,
+// on slices each element is filtered. Unknown fields are ignored, and every field is sent
+// when the parameter is not given. Values which implements json.Marshaler are not filtered inside.
+func Filtered(w http.ResponseWriter, r *http.Request, v interface{}, code int, opts ...Option) (int, error) {
+
+	c := newCall(r.Context(), opts...)
+
+	tree := parseFields(r.URL.Query()[fieldsParam])
+
+	if tree == nil {
+		return c.marshalled(w, v, code)
+	}
+
+	return c.marshalled(w, tree.filter(c.opts.shaper().shape(reflect.ValueOf(v))), code)
+}
+
+// fieldTree is the fields requested, by name, a nil tree keep the whole field
+type fieldTree map[string]fieldTree
+
+// parseFields return the tree of fields requested, nil when no field was requested
+func parseFields(params []string) fieldTree {
+
+	var tree fieldTree
+
+	for _, param := range params {
+
+		for _, path := range strings.Split(param, ",") {
+
+			path = strings.TrimSpace(path)
+
+			if path == "" {
+				continue
+			}
+
+			if tree == nil {
+				tree = make(fieldTree)
+			}
+
+			tree.add(strings.Split(path, "."))
+		}
+	}
+
+	return tree
+}
+
+// add keep the path of names, a field already kept entirely is not narrowed
+func (t fieldTree) add(names []string) {
+
+	name := names[0]
+
+	if len(names) == 1 {
+		t[name] = nil
+		return
+	}
+
+	child, ok := t[name]
+
+	if ok && child == nil {
+		return
+	}
+
+	if !ok {
+		child = make(fieldTree)
+		t[name] = child
+	}
+
+	child.add(names[1:])
+}
+
+// filter remove the fields which are not on tree from a shaped value
+func (t fieldTree) filter(v interface{}) interface{} {
+
+	if t == nil {
+		return v
+	}
+
+	switch value := v.(type) {
+	case object:
+		result := make(object, 0, len(t))
+		for _, field := range value {
+			if child, ok := t[field.name]; ok {
+				result = append(result, objectField{name: field.name, value: child.filter(field.value)})
+			}
+		}
+		return result
+	case map[string]interface{}:
+		result := make(map[string]interface{}, len(t))
+		for name, child := range t {
+			if item, ok := value[name]; ok {
+				result[name] = child.filter(item)
+			}
+		}
+		return result
+	case []interface{}:
+		items := make([]interface{}, len(value))
+		for i, item := range value {
+			items[i] = t.filter(item)
+		}
+		return items
+	default:
+		return v
+	}
+}
@@ -0,0 +1,72 @@
+package rest_test
+
+import (
+	"github.com/edermanoel94/rest-go"
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+type fieldsOwner struct {
+	ID    int    `json:"id"`
+	Name  string `json:"name"`
+	Email string `json:"email"`
+}
+
+type fieldsProduct struct {
+	ID       int               `json:"id"`
+	Name     string            `json:"name"`
+	Price    float64           `json:"price"`
+	Owner    *fieldsOwner      `json:"owner"`
+	Tags     []string          `json:"tags,omitempty"`
+	Metadata map[string]string `json:"metadata,omitempty"`
+}
+
+func TestFiltered(t *testing.T) {
+
+	product := &fieldsProduct{
+		ID:       1,
+		Name:     "tv",
+		Price:    10.5,
+		Owner:    &fieldsOwner{ID: 2, Name: "eder", Email: "eder@example.com"},
+		Tags:     []string{"home"},
+		Metadata: map[string]string{"color": "black", "size": "50"},
+	}
+
+	testCases := []struct {
+		description string
+		target      string
+		value       interface{}
+		body        string
+	}{
+		{"should send every field without parameter", "/products/1", product,
+			`{"id":1,"name":"tv","price":10.5,"owner":{"id":2,"name":"eder","email":"eder@example.com"},"tags":["home"],"metadata":{"color":"black","size":"50"}}`},
+		{"should keep fields requested on order of struct", "/products/1?fields=price,name", product,
+			`{"name":"tv","price":10.5}`},
+		{"should keep nested fields", "/products/1?fields=name,owner.id", product,
+			`{"name":"tv","owner":{"id":2}}`},
+		{"should keep whole field when also requested", "/products/1?fields=owner.id,owner", product,
+			`{"owner":{"id":2,"name":"eder","email":"eder@example.com"}}`},
+		{"should keep keys of maps", "/products/1?fields=metadata.color", product,
+			`{"metadata":{"color":"black"}}`},
+		{"should filter each element of slice", "/products?fields=id,owner.name", []*fieldsProduct{product, {ID: 3}},
+			`[{"id":1,"owner":{"name":"eder"}},{"id":3,"owner":null}]`},
+		{"should join many parameters and ignore unknown fields", "/products/1?fields=id&fields=%20unknown,,tags", product,
+			`{"id":1,"tags":["home"]}`},
+	}
+
+	for _, tc := range testCases {
+
+		t.Run(tc.description, func(t *testing.T) {
+
+			recorder := httptest.NewRecorder()
+
+			rest.Filtered(recorder, httptest.NewRequest(http.MethodGet, tc.target, nil), tc.value, http.StatusOK)
+
+			assert.Equal(t, http.StatusOK, recorder.Code)
+			assert.Equal(t, "application/json", recorder.Header().Get("Content-Type"))
+			assert.Equal(t, tc.body, recorder.Body.String())
+		})
+	}
+}
@@ -0,0 +1,48 @@
+package rest
+
+import (
+	"io"
+	"mime"
+	"net/http"
+	"path/filepath"
+	"time"
+)
+
+// File send content as a download named name, with Range and conditional requests handled by
+// http.ServeContent. The content type is found by extension of name, unless WithContentType is given,
+// and content is streamed without buffering.
+func File(w http.ResponseWriter, r *http.Request, name string, content io.ReadSeeker, opts ...Option) (int, error) {
+	return serveFile(w, r, name, content, "attachment", opts)
+}
+
+// Inline send content like File, but asking the browser to show it instead of downloading
+func Inline(w http.ResponseWriter, r *http.Request, name string, content io.ReadSeeker, opts ...Option) (int, error) {
+	return serveFile(w, r, name, content, "inline", opts)
+}
+
+func serveFile(w http.ResponseWriter, r *http.Request, name string, content io.ReadSeeker, disposition string, opts []Option) (int, error) {
+
+	c := newCall(r.Context(), opts...)
+
+	name = filepath.Base(name)
+
+	header := w.Header()
+
+	applyDefaultHeaders(header)
+
+	c.opts.apply(header, http.StatusOK)
+
+	if mediaType := c.opts.mediaType(mime.TypeByExtension(filepath.Ext(name))); mediaType != "" {
+		header.Set(contentType, mediaType)
+	}
+
+	header.Set("Content-Disposition", mime.FormatMediaType(disposition, map[string]string{"filename": name}))
+
+	rw := newResponseWriter(w)
+
+	http.ServeContent(rw, r, name, time.Time{}, content)
+
+	c.notify(header.Get(contentType), rw.status, int(rw.written), rw.err)
+
+	return int(rw.written), rw.err
+}
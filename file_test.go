@@ -0,0 +1,118 @@
+package rest_test
+
+import (
+	"github.com/edermanoel94/rest-go"
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestFile(t *testing.T) {
+
+	t.Run("should send content as attachment", func(t *testing.T) {
+
+		request := httptest.NewRequest(http.MethodGet, "/exports", nil)
+
+		recorder := httptest.NewRecorder()
+
+		rest.File(recorder, request, "exports/products.csv", strings.NewReader("id,name\n1,tv\n"))
+
+		assert.Equal(t, http.StatusOK, recorder.Code)
+		assert.Equal(t, `attachment; filename=products.csv`, recorder.Header().Get("Content-Disposition"))
+		assert.Equal(t, "text/csv; charset=utf-8", recorder.Header().Get("Content-Type"))
+		assert.Equal(t, "id,name\n1,tv\n", recorder.Body.String())
+	})
+
+	t.Run("should send content type given", func(t *testing.T) {
+
+		request := httptest.NewRequest(http.MethodGet, "/exports", nil)
+
+		recorder := httptest.NewRecorder()
+
+		rest.File(recorder, request, "report", strings.NewReader("%PDF-1.4"), rest.WithContentType("application/pdf"))
+
+		assert.Equal(t, "application/pdf", recorder.Header().Get("Content-Type"))
+	})
+
+	t.Run("should detect content type without extension", func(t *testing.T) {
+
+		request := httptest.NewRequest(http.MethodGet, "/exports", nil)
+
+		recorder := httptest.NewRecorder()
+
+		rest.File(recorder, request, "report", strings.NewReader("<html><body></body></html>"))
+
+		assert.Equal(t, "text/html; charset=utf-8", recorder.Header().Get("Content-Type"))
+	})
+
+	t.Run("should send a range of content", func(t *testing.T) {
+
+		request := httptest.NewRequest(http.MethodGet, "/exports", nil)
+		request.Header.Set("Range", "bytes=0-1")
+
+		recorder := httptest.NewRecorder()
+
+		rest.File(recorder, request, "products.csv", strings.NewReader("id,name"))
+
+		assert.Equal(t, http.StatusPartialContent, recorder.Code)
+		assert.Equal(t, "bytes 0-1/7", recorder.Header().Get("Content-Range"))
+		assert.Equal(t, "id", recorder.Body.String())
+	})
+
+	t.Run("should send options", func(t *testing.T) {
+
+		request := httptest.NewRequest(http.MethodGet, "/exports", nil)
+
+		recorder := httptest.NewRecorder()
+
+		rest.File(recorder, request, "products.csv", strings.NewReader("id"), rest.Private(), rest.MaxAge(time.Minute))
+
+		assert.Equal(t, "private, max-age=60", recorder.Header().Get("Cache-Control"))
+	})
+
+	t.Run("should encode name which is not ascii", func(t *testing.T) {
+
+		request := httptest.NewRequest(http.MethodGet, "/exports", nil)
+
+		recorder := httptest.NewRecorder()
+
+		rest.File(recorder, request, "relatório.txt", strings.NewReader("id"))
+
+		assert.Equal(t, "attachment; filename*=utf-8''relat%C3%B3rio.txt", recorder.Header().Get("Content-Disposition"))
+	})
+
+	t.Run("should return the error of writer", func(t *testing.T) {
+
+		var events []rest.Event
+
+		rest.OnWrite(func(event rest.Event) {
+			events = append(events, event)
+		})
+		defer rest.ResetWriteHooks()
+
+		request := httptest.NewRequest(http.MethodGet, "/exports", nil)
+
+		_, err := rest.File(brokenWriter{httptest.NewRecorder()}, request, "products.csv", strings.NewReader("id,name\n1,tv\n"))
+
+		assert.EqualError(t, err, "broken pipe")
+		assert.Equal(t, err, events[0].WriteErr)
+	})
+}
+
+func TestInline(t *testing.T) {
+
+	t.Run("should send content as inline", func(t *testing.T) {
+
+		request := httptest.NewRequest(http.MethodGet, "/images", nil)
+
+		recorder := httptest.NewRecorder()
+
+		rest.Inline(recorder, request, "logo.png", strings.NewReader("png"))
+
+		assert.Equal(t, "inline; filename=logo.png", recorder.Header().Get("Content-Disposition"))
+		assert.Equal(t, "image/png", recorder.Header().Get("Content-Type"))
+	})
+}
@@ -1,12 +1,153 @@
 package rest
 
 import (
-	"fmt"
+	"context"
+	"encoding/xml"
+	"errors"
 	"strings"
+	"sync"
 )
 
-// defaultJsonErrorMessage encapsulate an error in a json format
-func defaultJsonErrorMessage(err error) []byte {
-	sanitize := strings.ReplaceAll(err.Error(), "\"", "")
-	return []byte(fmt.Sprintf(`{"message":"%s"}`, sanitize))
+// errorMessage is the json sent by Error, or the xml sent by XMLError
+type errorMessage struct {
+	XMLName    xml.Name        `json:"-" xml:"error"`
+	Message    string          `json:"message" xml:"message"`
+	Code       string          `json:"code,omitempty" xml:"code,omitempty"`
+	Fields     []FieldError    `json:"fields,omitempty" xml:"field,omitempty"`
+	Errors     []*errorMessage `json:"errors,omitempty" xml:"error,omitempty"`
+	RetryAfter int             `json:"retry_after,omitempty" xml:"retry_after,omitempty"`
+	Details    interface{}     `json:"details,omitempty" xml:"details,omitempty"`
+	RequestID  string          `json:"request_id,omitempty" xml:"request_id,omitempty"`
+	Debug      *debugInfo      `json:"debug,omitempty" xml:"-"`
+}
+
+// internalErrorMessage is sent when a error message cannot be encoded
+var internalErrorMessage = []byte(`{"message":"internal server error"}`)
+
+// ErrorEnvelope create the body of a error sent with status, like {"error":{"code":...,"message":...}}
+type ErrorEnvelope func(err error, status int) interface{}
+
+var (
+	errorEnvelopeMu sync.RWMutex
+	errorEnvelope   ErrorEnvelope
+)
+
+// SetErrorEnvelope change the body of every error sent by the package, a nil envelope
+// restore the default {"message":...,"request_id":...}. Errors which are not pointers
+// keep sending err.Error() as body, like before, and the debug field is not added.
+func SetErrorEnvelope(envelope ErrorEnvelope) {
+	errorEnvelopeMu.Lock()
+	defer errorEnvelopeMu.Unlock()
+	errorEnvelope = envelope
+}
+
+// errorBody return the value encoded as error, with the envelope when is set
+func errorBody(ctx context.Context, mediaType string, err error, status int) interface{} {
+
+	errorEnvelopeMu.RLock()
+	envelope := errorEnvelope
+	errorEnvelopeMu.RUnlock()
+
+	if envelope != nil {
+		return envelope(err, status)
+	}
+
+	body := newErrorMessage(ctx, mediaType, err)
+
+	body.RequestID = RequestIDFromContext(ctx)
+
+	if debugEnabled(ctx) {
+		body.Debug = newDebugInfo(err)
+	}
+
+	return body
+}
+
+// newErrorMessage create the message of err, a multi error have the message of each error on errors.
+// Localized errors and validation messages are translated to the language of context.
+func newErrorMessage(ctx context.Context, mediaType string, err error) *errorMessage {
+
+	message := err.Error()
+
+	body := &errorMessage{}
+
+	defer func() {
+		if mediaType == applicationJson {
+			body.Message = strings.ReplaceAll(body.Message, "\"", "")
+		}
+	}()
+
+	if errs, ok := multiErrors(err); ok {
+		body.Message = message
+		for _, item := range errs {
+			body.Errors = append(body.Errors, newErrorMessage(ctx, mediaType, item))
+		}
+		return body
+	}
+
+	var localized *localizedError
+
+	if errors.As(err, &localized) {
+		message = strings.Replace(message, localized.Error(), T(ctx, localized.key, localized.args...), 1)
+	}
+
+	var coder Coder
+
+	if errors.As(err, &coder) {
+		body.Code = coder.ErrorCode()
+	}
+
+	var validationErr *ValidationError
+
+	if errors.As(err, &validationErr) {
+		body.Fields = translateFields(ctx, validationErr.Fields)
+		for i, field := range validationErr.Fields {
+			message = strings.Replace(message, field.Message, body.Fields[i].Message, 1)
+		}
+	}
+
+	var httpErr *HTTPError
+
+	if errors.As(err, &httpErr) {
+		body.Details = httpErr.Details
+	}
+
+	var unavailable *UnavailableError
+
+	if errors.As(err, &unavailable) {
+		body.RetryAfter = unavailable.seconds()
+	}
+
+	body.Message = message
+
+	return body
+}
+
+// defaultJsonErrorMessage encapsulate an error in a json format, with the request id of context
+func defaultJsonErrorMessage(ctx context.Context, err error, status int) []byte {
+
+	bytes, encodeErr := encode(applicationJson, errorBody(ctx, applicationJson, err, status))
+
+	if encodeErr != nil {
+		return internalErrorMessage
+	}
+
+	return bytes
+}
+
+// errorMessageOf encapsulate an error in the media type given, falling back to json when
+// the encoder of media type cannot encode it, the media type of message is returned
+func errorMessageOf(ctx context.Context, mediaType string, err error, status int) (string, []byte) {
+
+	if mediaType == applicationJson {
+		return applicationJson, defaultJsonErrorMessage(ctx, err, status)
+	}
+
+	bytes, encodeErr := encode(mediaType, errorBody(ctx, mediaType, err, status))
+
+	if encodeErr != nil {
+		return applicationJson, defaultJsonErrorMessage(ctx, err, status)
+	}
+
+	return mediaType, bytes
 }
@@ -0,0 +1,76 @@
+package rest_test
+
+import (
+	"errors"
+	"github.com/edermanoel94/rest-go"
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+type styleGuideError struct {
+	Error struct {
+		Code    int    `json:"code"`
+		Message string `json:"message"`
+	} `json:"error"`
+}
+
+func styleGuideEnvelope(err error, status int) interface{} {
+	envelope := styleGuideError{}
+	envelope.Error.Code = status
+	envelope.Error.Message = err.Error()
+	return envelope
+}
+
+func TestSetErrorEnvelope(t *testing.T) {
+
+	t.Run("should send errors on envelope", func(t *testing.T) {
+
+		rest.SetErrorEnvelope(styleGuideEnvelope)
+		defer rest.SetErrorEnvelope(nil)
+
+		recorder := httptest.NewRecorder()
+
+		rest.Error(recorder, errors.New(`product "tv" not found`), http.StatusNotFound)
+
+		assert.Equal(t, http.StatusNotFound, recorder.Code)
+		assert.Equal(t, `{"error":{"code":404,"message":"product \"tv\" not found"}}`, recorder.Body.String())
+	})
+
+	t.Run("should send status resolved to envelope", func(t *testing.T) {
+
+		rest.SetErrorEnvelope(styleGuideEnvelope)
+		defer rest.SetErrorEnvelope(nil)
+
+		recorder := httptest.NewRecorder()
+
+		rest.Error(recorder, &forbiddenError{reason: "forbidden"}, http.StatusBadRequest)
+
+		assert.Equal(t, `{"error":{"code":403,"message":"forbidden"}}`, recorder.Body.String())
+	})
+
+	t.Run("should send invalid json error on envelope", func(t *testing.T) {
+
+		rest.SetErrorEnvelope(styleGuideEnvelope)
+		defer rest.SetErrorEnvelope(nil)
+
+		recorder := httptest.NewRecorder()
+
+		rest.Response(recorder, []byte(`{`), http.StatusOK)
+
+		assert.Equal(t, `{"error":{"code":500,"message":"not a valid json"}}`, recorder.Body.String())
+	})
+
+	t.Run("should restore default envelope with nil", func(t *testing.T) {
+
+		rest.SetErrorEnvelope(styleGuideEnvelope)
+		rest.SetErrorEnvelope(nil)
+
+		recorder := httptest.NewRecorder()
+
+		rest.Error(recorder, errors.New("not found"), http.StatusNotFound)
+
+		assert.Equal(t, `{"message":"not found"}`, recorder.Body.String())
+	})
+}
@@ -0,0 +1,22 @@
+package rest
+
+import (
+	"context"
+	"net/http"
+)
+
+// Result is a typed envelope for a response, like {"data": {...}}
+type Result[T any] struct {
+	Data    T      `json:"data"`
+	Message string `json:"message,omitempty"`
+}
+
+// JSON marshall a typed value and respond json, there is no need to pass a pointer like Marshalled
+func JSON[T any](w http.ResponseWriter, v T, code int) (int, error) {
+	return newCall(context.Background()).marshalled(w, v, code)
+}
+
+// Respond marshall data inside of Result and respond json
+func Respond[T any](w http.ResponseWriter, data T, code int) (int, error) {
+	return JSON(w, Result[T]{Data: data}, code)
+}
@@ -0,0 +1,46 @@
+package rest_test
+
+import (
+	"github.com/edermanoel94/rest-go"
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestJSON(t *testing.T) {
+
+	type product struct {
+		Name string `json:"name"`
+	}
+
+	t.Run("should marshal a typed value", func(t *testing.T) {
+
+		recorder := httptest.NewRecorder()
+
+		rest.JSON(recorder, product{Name: "tv"}, http.StatusOK)
+
+		assert.Equal(t, http.StatusOK, recorder.Code)
+		assert.Equal(t, "application/json", recorder.Header().Get("Content-Type"))
+		assert.Equal(t, `{"name":"tv"}`, recorder.Body.String())
+	})
+
+	t.Run("should marshal a typed envelope", func(t *testing.T) {
+
+		recorder := httptest.NewRecorder()
+
+		rest.Respond(recorder, []product{{Name: "tv"}}, http.StatusOK)
+
+		assert.Equal(t, `{"data":[{"name":"tv"}]}`, recorder.Body.String())
+	})
+
+	t.Run("should marshal a result with message", func(t *testing.T) {
+
+		recorder := httptest.NewRecorder()
+
+		rest.JSON(recorder, rest.Result[int]{Data: 1, Message: "created"}, http.StatusCreated)
+
+		assert.Equal(t, http.StatusCreated, recorder.Code)
+		assert.Equal(t, `{"data":1,"message":"created"}`, recorder.Body.String())
+	})
+}
@@ -0,0 +1,47 @@
+package rest
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"reflect"
+)
+
+const applicationHalJson = "application/hal+json"
+
+// Link is a HAL link, templated links have variables like /products{?page}
+type Link struct {
+	Href      string `json:"href"`
+	Title     string `json:"title,omitempty"`
+	Templated bool   `json:"templated,omitempty"`
+}
+
+// Links are the HAL links of a resource by relation, like self, next and related
+type Links map[string]Link
+
+// Add set the link of relation, returning links to chain many calls
+func (l Links) Add(rel, href string) Links {
+	l[rel] = Link{Href: href}
+	return l
+}
+
+// HAL marshall a resource with links on _links field and respond application/hal+json,
+// the resource must be a struct or a map
+func HAL(w http.ResponseWriter, resource interface{}, links Links, code int, opts ...Option) (int, error) {
+
+	c := newCall(context.Background(), append([]Option{WithContentType(applicationHalJson)}, opts...)...)
+
+	var shaped interface{}
+
+	switch value := c.opts.shaper().shape(reflect.ValueOf(resource)).(type) {
+	case object:
+		shaped = value.set("_links", links)
+	case map[string]interface{}:
+		value["_links"] = links
+		shaped = value
+	default:
+		return c.fail(w, fmt.Errorf("cannot add links to %T, must be a struct or map", resource), http.StatusInternalServerError)
+	}
+
+	return c.marshalled(w, shaped, code)
+}
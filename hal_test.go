@@ -0,0 +1,51 @@
+package rest_test
+
+import (
+	"github.com/edermanoel94/rest-go"
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestHAL(t *testing.T) {
+
+	type product struct {
+		ID   int    `json:"id"`
+		Name string `json:"name"`
+	}
+
+	t.Run("should send links of resource", func(t *testing.T) {
+
+		recorder := httptest.NewRecorder()
+
+		links := rest.Links{}.Add("self", "/products/1").Add("category", "/categories/2")
+
+		rest.HAL(recorder, &product{ID: 1, Name: "tv"}, links, http.StatusOK)
+
+		assert.Equal(t, http.StatusOK, recorder.Code)
+		assert.Equal(t, "application/hal+json", recorder.Header().Get("Content-Type"))
+		assert.Equal(t, `{"id":1,"name":"tv","_links":{"category":{"href":"/categories/2"},"self":{"href":"/products/1"}}}`, recorder.Body.String())
+	})
+
+	t.Run("should send templated links", func(t *testing.T) {
+
+		recorder := httptest.NewRecorder()
+
+		links := rest.Links{"search": {Href: "/products{?name}", Templated: true}}
+
+		rest.HAL(recorder, map[string]interface{}{"total": 2}, links, http.StatusOK)
+
+		assert.Equal(t, `{"_links":{"search":{"href":"/products{?name}","templated":true}},"total":2}`, recorder.Body.String())
+	})
+
+	t.Run("should send a error when resource cannot have links", func(t *testing.T) {
+
+		recorder := httptest.NewRecorder()
+
+		rest.HAL(recorder, []int{1}, rest.Links{}.Add("self", "/products"), http.StatusOK)
+
+		assert.Equal(t, http.StatusInternalServerError, recorder.Code)
+		assert.Equal(t, "application/json", recorder.Header().Get("Content-Type"))
+	})
+}
@@ -0,0 +1,50 @@
+package rest
+
+import (
+	"errors"
+	"net/http"
+	"strings"
+)
+
+var (
+	ErrNotFound         = errors.New("not found")
+	ErrMethodNotAllowed = errors.New("method not allowed")
+)
+
+// HandlerFunc is a handler which return the value to respond or a error
+type HandlerFunc func(r *http.Request) (interface{}, error)
+
+// Handler adapt a HandlerFunc to http.HandlerFunc, the value returned is sent like Marshalled
+// with 200 and the error like Error with the status of DefaultErrorMapper, falling back to 500
+func Handler(handler HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+
+		v, err := handler(r)
+
+		if err != nil {
+			newCall(r.Context()).fail(w, err, 0)
+			return
+		}
+
+		newCall(r.Context()).marshalled(w, v, http.StatusOK)
+	}
+}
+
+// NotFoundHandler respond 404 with ErrNotFound as json error, like the NotFound handler of a router
+func NotFoundHandler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		newCall(r.Context()).fail(w, ErrNotFound, http.StatusNotFound)
+	})
+}
+
+// MethodNotAllowedHandler respond 405 with ErrMethodNotAllowed as json error, sending the
+// methods allowed on Allow header, like the MethodNotAllowed handler of a router
+func MethodNotAllowedHandler(allowed ...string) http.Handler {
+
+	allow := strings.Join(allowed, ", ")
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Allow", allow)
+		newCall(r.Context()).fail(w, ErrMethodNotAllowed, http.StatusMethodNotAllowed)
+	})
+}
@@ -0,0 +1,106 @@
+package rest_test
+
+import (
+	"errors"
+	"github.com/edermanoel94/rest-go"
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+var errHandlerMissing = errors.New("product is missing")
+
+func TestHandler(t *testing.T) {
+
+	rest.MapError(errHandlerMissing, http.StatusNotFound)
+
+	type product struct {
+		Name string `json:"name"`
+	}
+
+	testCases := []struct {
+		description string
+		handler     rest.HandlerFunc
+		body        string
+		statusCode  int
+		expected    string
+	}{
+		{"should marshal the value returned", func(r *http.Request) (interface{}, error) {
+			return &product{Name: "tv"}, nil
+		}, "", http.StatusOK, `{"name":"tv"}`},
+		{"should respond 500 on error", func(r *http.Request) (interface{}, error) {
+			return nil, errors.New("database is down")
+		}, "", http.StatusInternalServerError, `{"message":"database is down"}`},
+		{"should use the status code of error", func(r *http.Request) (interface{}, error) {
+			result := product{}
+			if err := rest.Bind(r, &result); err != nil {
+				return nil, err
+			}
+			return &result, nil
+		}, `{"name":`, http.StatusBadRequest, `{"message":"request body have a malformed json"}`},
+		{"should use the status mapped to error", func(r *http.Request) (interface{}, error) {
+			return nil, errHandlerMissing
+		}, "", http.StatusNotFound, `{"message":"product is missing"}`},
+	}
+
+	for _, tc := range testCases {
+
+		t.Run(tc.description, func(t *testing.T) {
+
+			request := httptest.NewRequest(http.MethodPost, "/", strings.NewReader(tc.body))
+			request.Header.Set("Content-Type", "application/json")
+
+			recorder := httptest.NewRecorder()
+
+			rest.Handler(tc.handler).ServeHTTP(recorder, request)
+
+			assert.Equal(t, tc.statusCode, recorder.Code)
+			assert.Equal(t, tc.expected, recorder.Body.String())
+		})
+	}
+}
+
+func TestNotFoundHandler(t *testing.T) {
+
+	t.Run("should respond not found as json", func(t *testing.T) {
+
+		request := httptest.NewRequest(http.MethodGet, "/unknown", nil)
+		request = request.WithContext(rest.WithRequestID(request.Context(), "abc"))
+
+		recorder := httptest.NewRecorder()
+
+		rest.NotFoundHandler().ServeHTTP(recorder, request)
+
+		assert.Equal(t, http.StatusNotFound, recorder.Code)
+		assert.Equal(t, "application/json", recorder.Header().Get("Content-Type"))
+		assert.Equal(t, `{"message":"not found","request_id":"abc"}`, recorder.Body.String())
+	})
+}
+
+func TestMethodNotAllowedHandler(t *testing.T) {
+
+	testCases := []struct {
+		description string
+		allowed     []string
+		allow       string
+	}{
+		{"should send the methods allowed", []string{http.MethodGet, http.MethodPost}, "GET, POST"},
+		{"should send empty allow without methods", nil, ""},
+	}
+
+	for _, tc := range testCases {
+
+		t.Run(tc.description, func(t *testing.T) {
+
+			recorder := httptest.NewRecorder()
+
+			rest.MethodNotAllowedHandler(tc.allowed...).ServeHTTP(recorder, httptest.NewRequest(http.MethodDelete, "/products", nil))
+
+			assert.Equal(t, http.StatusMethodNotAllowed, recorder.Code)
+			assert.Equal(t, []string{tc.allow}, recorder.Header()["Allow"])
+			assert.Equal(t, `{"message":"method not allowed"}`, recorder.Body.String())
+		})
+	}
+}
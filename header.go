@@ -0,0 +1,39 @@
+package rest
+
+import (
+	"net/http"
+	"sync"
+)
+
+var (
+	defaultHeadersMu sync.RWMutex
+	defaultHeaders   = make(map[string]string)
+)
+
+// SetDefaultHeaders replace the headers sent by every write helper of the package.
+// A header already set on the writer before calling a helper is not overwritten.
+func SetDefaultHeaders(headers map[string]string) {
+
+	copied := make(map[string]string, len(headers))
+
+	for key, value := range headers {
+		copied[http.CanonicalHeaderKey(key)] = value
+	}
+
+	defaultHeadersMu.Lock()
+	defaultHeaders = copied
+	defaultHeadersMu.Unlock()
+}
+
+// applyDefaultHeaders set the default headers which the caller don't set yet
+func applyDefaultHeaders(header http.Header) {
+
+	defaultHeadersMu.RLock()
+	defer defaultHeadersMu.RUnlock()
+
+	for key, value := range defaultHeaders {
+		if _, ok := header[key]; !ok {
+			header.Set(key, value)
+		}
+	}
+}
@@ -0,0 +1,50 @@
+package rest_test
+
+import (
+	"errors"
+	"github.com/edermanoel94/rest-go"
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestSetDefaultHeaders(t *testing.T) {
+
+	rest.SetDefaultHeaders(map[string]string{
+		"x-frame-options": "DENY",
+		"Cache-Control":   "no-store",
+	})
+
+	defer rest.SetDefaultHeaders(nil)
+
+	t.Run("should send default headers on every helper", func(t *testing.T) {
+
+		recorders := []*httptest.ResponseRecorder{
+			httptest.NewRecorder(),
+			httptest.NewRecorder(),
+			httptest.NewRecorder(),
+		}
+
+		rest.Response(recorders[0], []byte(`{"name":"cale"}`), http.StatusOK)
+		rest.Marshalled(recorders[1], map[string]string{"name": "cale"}, http.StatusOK)
+		rest.Error(recorders[2], errors.New("not found"), http.StatusNotFound)
+
+		for _, recorder := range recorders {
+			assert.Equal(t, "DENY", recorder.Header().Get("X-Frame-Options"))
+			assert.Equal(t, "no-store", recorder.Header().Get("Cache-Control"))
+		}
+	})
+
+	t.Run("should not override a header set by the caller", func(t *testing.T) {
+
+		recorder := httptest.NewRecorder()
+
+		recorder.Header().Set("Cache-Control", "max-age=60")
+
+		rest.Response(recorder, []byte(`{"name":"cale"}`), http.StatusOK)
+
+		assert.Equal(t, "max-age=60", recorder.Header().Get("Cache-Control"))
+		assert.Equal(t, "DENY", recorder.Header().Get("X-Frame-Options"))
+	})
+}
@@ -0,0 +1,126 @@
+package rest
+
+import (
+	"context"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// DefaultCheckTimeout is the timeout of a Check without Timeout
+const DefaultCheckTimeout = 2 * time.Second
+
+const (
+	statusOk   = "ok"
+	statusFail = "fail"
+)
+
+// Check is a dependency checked by Ready, like a ping on database
+type Check struct {
+	Name    string
+	Check   func(ctx context.Context) error
+	Timeout time.Duration
+}
+
+// CheckReport is the result of a check sent by Ready
+type CheckReport struct {
+	Name    string `json:"name"`
+	Status  string `json:"status"`
+	Latency string `json:"latency"`
+	Error   string `json:"error,omitempty"`
+}
+
+// HealthReport is the json sent by Health and Ready
+type HealthReport struct {
+	Status string        `json:"status"`
+	Checks []CheckReport `json:"checks,omitempty"`
+}
+
+// Health respond 200 with {"status":"ok"} while the process is alive, like /healthz
+func Health() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		Marshalled(w, &HealthReport{Status: statusOk}, http.StatusOK, NoStore())
+	})
+}
+
+// Ready run the checks concurrently, each one with its timeout, and respond 200 when all pass,
+// or 503 when one fail, with the status and latency of each check
+func Ready(checks ...Check) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+
+		report := runChecks(r.Context(), checks)
+
+		code := http.StatusOK
+
+		if report.Status != statusOk {
+			code = http.StatusServiceUnavailable
+		}
+
+		Marshalled(w, report, code, NoStore())
+	})
+}
+
+func runChecks(ctx context.Context, checks []Check) *HealthReport {
+
+	report := &HealthReport{Status: statusOk, Checks: make([]CheckReport, len(checks))}
+
+	var wg sync.WaitGroup
+
+	for i, check := range checks {
+
+		wg.Add(1)
+
+		go func(i int, check Check) {
+			defer wg.Done()
+			report.Checks[i] = runCheck(ctx, check)
+		}(i, check)
+	}
+
+	wg.Wait()
+
+	for _, check := range report.Checks {
+		if check.Status != statusOk {
+			report.Status = statusFail
+		}
+	}
+
+	return report
+}
+
+// runCheck run a check until its timeout, a check which don't return after timeout fail
+func runCheck(ctx context.Context, check Check) CheckReport {
+
+	timeout := check.Timeout
+
+	if timeout <= 0 {
+		timeout = DefaultCheckTimeout
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	start := time.Now()
+
+	done := make(chan error, 1)
+
+	go func() {
+		done <- check.Check(ctx)
+	}()
+
+	var err error
+
+	select {
+	case err = <-done:
+	case <-ctx.Done():
+		err = ctx.Err()
+	}
+
+	result := CheckReport{Name: check.Name, Status: statusOk, Latency: time.Since(start).String()}
+
+	if err != nil {
+		result.Status = statusFail
+		result.Error = err.Error()
+	}
+
+	return result
+}
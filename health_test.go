@@ -0,0 +1,85 @@
+package rest_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"github.com/edermanoel94/rest-go"
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestHealth(t *testing.T) {
+
+	t.Run("should respond ok", func(t *testing.T) {
+
+		recorder := httptest.NewRecorder()
+
+		rest.Health().ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/healthz", nil))
+
+		assert.Equal(t, http.StatusOK, recorder.Code)
+		assert.Equal(t, "no-store", recorder.Header().Get("Cache-Control"))
+		assert.Equal(t, `{"status":"ok"}`, recorder.Body.String())
+	})
+}
+
+func TestReady(t *testing.T) {
+
+	database := rest.Check{Name: "database", Check: func(ctx context.Context) error {
+		return nil
+	}}
+
+	cache := rest.Check{Name: "cache", Check: func(ctx context.Context) error {
+		return errors.New("connection refused")
+	}}
+
+	slow := rest.Check{Name: "search", Timeout: 10 * time.Millisecond, Check: func(ctx context.Context) error {
+		time.Sleep(time.Second)
+		return nil
+	}}
+
+	testCases := []struct {
+		description string
+		checks      []rest.Check
+		statusCode  int
+		status      string
+		statuses    []string
+		errors      []string
+	}{
+		{"should respond ok when checks pass", []rest.Check{database}, http.StatusOK, "ok",
+			[]string{"ok"}, []string{""}},
+		{"should respond unavailable when a check fail", []rest.Check{database, cache}, http.StatusServiceUnavailable, "fail",
+			[]string{"ok", "fail"}, []string{"", "connection refused"}},
+		{"should fail a check after timeout", []rest.Check{slow, database}, http.StatusServiceUnavailable, "fail",
+			[]string{"fail", "ok"}, []string{"context deadline exceeded", ""}},
+	}
+
+	for _, tc := range testCases {
+
+		t.Run(tc.description, func(t *testing.T) {
+
+			recorder := httptest.NewRecorder()
+
+			rest.Ready(tc.checks...).ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/readyz", nil))
+
+			var report rest.HealthReport
+
+			err := json.Unmarshal(recorder.Body.Bytes(), &report)
+
+			assert.NoError(t, err)
+			assert.Equal(t, tc.statusCode, recorder.Code)
+			assert.Equal(t, tc.status, report.Status)
+			assert.Len(t, report.Checks, len(tc.checks))
+
+			for i, check := range report.Checks {
+				assert.Equal(t, tc.checks[i].Name, check.Name)
+				assert.Equal(t, tc.statuses[i], check.Status)
+				assert.Equal(t, tc.errors[i], check.Error)
+				assert.NotEmpty(t, check.Latency)
+			}
+		})
+	}
+}
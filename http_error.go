@@ -0,0 +1,75 @@
+package rest
+
+import (
+	"errors"
+	"net/http"
+)
+
+// HTTPError is a error with the status, code and details to respond, Error send it like
+// {"message":"stock is empty","code":"out_of_stock","details":{"available":0}}
+type HTTPError struct {
+	Status  int
+	Code    string
+	Message string
+	// Details is sent on details field, it must be encodable on json
+	Details interface{}
+	// Err is the cause of error, its message is sent when Message is empty
+	Err error
+}
+
+// NewError create a error with code, message and status, like NewError("out_of_stock", "stock is empty", 409)
+func NewError(code string, message string, status int) *HTTPError {
+	return &HTTPError{Status: status, Code: code, Message: message}
+}
+
+// WrapStatus wrap err with the status to respond, keeping err on the chain, a nil err return nil
+func WrapStatus(err error, status int) error {
+
+	if err == nil {
+		return nil
+	}
+
+	return &HTTPError{Status: status, Err: err}
+}
+
+// WithDetails set the details of error, returning the error
+func (e *HTTPError) WithDetails(details interface{}) *HTTPError {
+	e.Details = details
+	return e
+}
+
+func (e *HTTPError) Error() string {
+
+	if e.Message == "" && e.Err != nil {
+		return e.Err.Error()
+	}
+
+	return e.Message
+}
+
+// Unwrap return the cause of error
+func (e *HTTPError) Unwrap() error {
+	return e.Err
+}
+
+// StatusCode return the status of error, 500 when is not set
+func (e *HTTPError) StatusCode() int {
+
+	if e.Status == 0 {
+		return http.StatusInternalServerError
+	}
+
+	return e.Status
+}
+
+// ErrorCode return the code of error, or the code of its cause when is not set
+func (e *HTTPError) ErrorCode() string {
+
+	var coder Coder
+
+	if e.Code == "" && errors.As(e.Err, &coder) {
+		return coder.ErrorCode()
+	}
+
+	return e.Code
+}
@@ -0,0 +1,57 @@
+package rest_test
+
+import (
+	"errors"
+	"fmt"
+	"github.com/edermanoel94/rest-go"
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+var errStockEmpty = errors.New("stock is empty")
+
+func TestHTTPError(t *testing.T) {
+
+	testCases := []struct {
+		description string
+		err         error
+		statusCode  int
+		body        string
+	}{
+		{"should send status, code and message", rest.NewError("out_of_stock", "stock is empty", http.StatusConflict),
+			http.StatusConflict, `{"message":"stock is empty","code":"out_of_stock"}`},
+		{"should send details", rest.NewError("out_of_stock", "stock is empty", http.StatusConflict).WithDetails(map[string]int{"available": 0}),
+			http.StatusConflict, `{"message":"stock is empty","code":"out_of_stock","details":{"available":0}}`},
+		{"should send message of wrapped error", rest.WrapStatus(errStockEmpty, http.StatusConflict),
+			http.StatusConflict, `{"message":"stock is empty"}`},
+		{"should send status of error wrapped again", fmt.Errorf("order: %w", rest.WrapStatus(errStockEmpty, http.StatusConflict)),
+			http.StatusConflict, `{"message":"order: stock is empty"}`},
+		{"should send code of cause", rest.WrapStatus(rest.NewError("out_of_stock", "stock is empty", http.StatusConflict), http.StatusUnprocessableEntity),
+			http.StatusUnprocessableEntity, `{"message":"stock is empty","code":"out_of_stock"}`},
+		{"should send internal server error without status", &rest.HTTPError{Message: "broken"},
+			http.StatusInternalServerError, `{"message":"broken"}`},
+	}
+
+	for _, tc := range testCases {
+
+		t.Run(tc.description, func(t *testing.T) {
+
+			recorder := httptest.NewRecorder()
+
+			rest.Error(recorder, tc.err, http.StatusBadRequest)
+
+			assert.Equal(t, tc.statusCode, recorder.Code)
+			assert.Equal(t, tc.body, recorder.Body.String())
+		})
+	}
+
+	t.Run("should keep the cause on chain", func(t *testing.T) {
+
+		err := rest.WrapStatus(errStockEmpty, http.StatusConflict)
+
+		assert.True(t, errors.Is(err, errStockEmpty))
+		assert.Nil(t, rest.WrapStatus(nil, http.StatusConflict))
+	})
+}
@@ -0,0 +1,324 @@
+package rest
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// PluralRule report if a count use the singular form of a message
+type PluralRule func(n int) bool
+
+// Catalog keep the messages by language, a message can have singular and plural forms
+type Catalog struct {
+	mu       sync.RWMutex
+	fallback string
+	messages map[string]map[string]message
+	rules    map[string]PluralRule
+}
+
+type message struct {
+	one   string
+	other string
+}
+
+// DefaultCatalog is the catalog used by T, LocalizedError and Message
+var DefaultCatalog = NewCatalog("en")
+
+type languageKey struct{}
+
+// NewCatalog create a catalog which use fallback language when a message don't exist on requested language
+func NewCatalog(fallback string) *Catalog {
+	return &Catalog{
+		fallback: normalizeLanguage(fallback),
+		messages: make(map[string]map[string]message),
+		rules:    make(map[string]PluralRule),
+	}
+}
+
+// Set add a message to language, text is formatted with fmt.Sprintf when args are given
+func (c *Catalog) Set(lang, key, text string) {
+	c.set(lang, key, message{one: text, other: text})
+}
+
+// SetPlural add a message with singular and plural forms, the first argument given to Translate is the count
+func (c *Catalog) SetPlural(lang, key, one, other string) {
+	c.set(lang, key, message{one: one, other: other})
+}
+
+// SetMessages add many messages to language
+func (c *Catalog) SetMessages(lang string, messages map[string]string) {
+	for key, text := range messages {
+		c.Set(lang, key, text)
+	}
+}
+
+// SetPluralRule change how language choose between singular and plural, by default just 1 is singular
+func (c *Catalog) SetPluralRule(lang string, rule PluralRule) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.rules[normalizeLanguage(lang)] = rule
+}
+
+func (c *Catalog) set(lang, key string, msg message) {
+
+	lang = normalizeLanguage(lang)
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if _, ok := c.messages[lang]; !ok {
+		c.messages[lang] = make(map[string]message)
+	}
+
+	c.messages[lang][key] = msg
+}
+
+// Languages return the languages which have messages on catalog
+func (c *Catalog) Languages() []string {
+
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	languages := make([]string, 0, len(c.messages))
+
+	for lang := range c.messages {
+		languages = append(languages, lang)
+	}
+
+	sort.Strings(languages)
+
+	return languages
+}
+
+// Translate return the message of key on language, looking at base language (pt for pt-br) and
+// fallback language, if message don't exist the key is returned
+func (c *Catalog) Translate(lang, key string, args ...interface{}) string {
+
+	if text, ok := c.translate(lang, key, args...); ok {
+		return text
+	}
+
+	return key
+}
+
+// translate is like Translate, but report false when the message don't exist
+func (c *Catalog) translate(lang, key string, args ...interface{}) (string, bool) {
+
+	c.mu.RLock()
+	msg, rule, ok := c.lookup(normalizeLanguage(lang), key)
+	c.mu.RUnlock()
+
+	if !ok {
+		return "", false
+	}
+
+	text := msg.other
+
+	if len(args) > 0 {
+		if n, ok := count(args[0]); ok && rule(n) {
+			text = msg.one
+		}
+		return fmt.Sprintf(text, args...), true
+	}
+
+	return text, true
+}
+
+func (c *Catalog) lookup(lang, key string) (message, PluralRule, bool) {
+
+	candidates := []string{lang}
+
+	if i := strings.Index(lang, "-"); i != -1 {
+		candidates = append(candidates, lang[:i])
+	}
+
+	candidates = append(candidates, c.fallback)
+
+	for _, candidate := range candidates {
+		if msg, ok := c.messages[candidate][key]; ok {
+			return msg, c.rule(candidate), true
+		}
+	}
+
+	return message{}, nil, false
+}
+
+func (c *Catalog) rule(lang string) PluralRule {
+
+	if rule, ok := c.rules[lang]; ok {
+		return rule
+	}
+
+	if i := strings.Index(lang, "-"); i != -1 {
+		if rule, ok := c.rules[lang[:i]]; ok {
+			return rule
+		}
+	}
+
+	return func(n int) bool {
+		return n == 1
+	}
+}
+
+func count(arg interface{}) (int, bool) {
+	switch n := arg.(type) {
+	case int:
+		return n, true
+	case int8:
+		return int(n), true
+	case int16:
+		return int(n), true
+	case int32:
+		return int(n), true
+	case int64:
+		return int(n), true
+	case uint:
+		return int(n), true
+	case uint8:
+		return int(n), true
+	case uint16:
+		return int(n), true
+	case uint32:
+		return int(n), true
+	case uint64:
+		return int(n), true
+	}
+	return 0, false
+}
+
+// T translate a message of DefaultCatalog to language on context
+func T(ctx context.Context, key string, args ...interface{}) string {
+	return DefaultCatalog.Translate(LanguageFromContext(ctx), key, args...)
+}
+
+// WithLanguage attach a language to context, used by T
+func WithLanguage(ctx context.Context, lang string) context.Context {
+	return context.WithValue(ctx, languageKey{}, normalizeLanguage(lang))
+}
+
+// LanguageFromContext return the language attached to context, or a empty string
+func LanguageFromContext(ctx context.Context) string {
+	if ctx == nil {
+		return ""
+	}
+	lang, _ := ctx.Value(languageKey{}).(string)
+	return lang
+}
+
+// NegotiateLanguage choose the best language of supported for Accept-Language header,
+// when nothing match the first supported language is returned
+func NegotiateLanguage(r *http.Request, supported ...string) string {
+
+	if len(supported) == 0 {
+		return ""
+	}
+
+	for _, accepted := range parseAcceptLanguage(r.Header.Get("Accept-Language")) {
+
+		for _, lang := range supported {
+			if normalizeLanguage(lang) == accepted {
+				return lang
+			}
+		}
+
+		base := accepted
+		if i := strings.Index(accepted, "-"); i != -1 {
+			base = accepted[:i]
+		}
+
+		for _, lang := range supported {
+			normalized := normalizeLanguage(lang)
+			if normalized == base || strings.HasPrefix(normalized, base+"-") {
+				return lang
+			}
+		}
+	}
+
+	return supported[0]
+}
+
+// Localize is a middleware which negotiate the language of request and attach it to the context
+func Localize(supported ...string) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			lang := NegotiateLanguage(r, supported...)
+			w.Header().Set("Content-Language", lang)
+			next.ServeHTTP(w, r.WithContext(WithLanguage(r.Context(), lang)))
+		})
+	}
+}
+
+// parseAcceptLanguage return the languages ordered by quality
+func parseAcceptLanguage(header string) []string {
+
+	type weighted struct {
+		lang    string
+		quality float64
+	}
+
+	languages := make([]weighted, 0)
+
+	for _, part := range strings.Split(header, ",") {
+
+		lang, quality := part, 1.0
+
+		if i := strings.Index(part, ";"); i != -1 {
+			lang = part[:i]
+			if q := strings.TrimSpace(part[i+1:]); strings.HasPrefix(q, "q=") {
+				if value, err := strconv.ParseFloat(q[2:], 64); err == nil {
+					quality = value
+				}
+			}
+		}
+
+		lang = normalizeLanguage(lang)
+
+		if lang == "" || lang == "*" || quality <= 0 {
+			continue
+		}
+
+		languages = append(languages, weighted{lang, quality})
+	}
+
+	sort.SliceStable(languages, func(i, j int) bool {
+		return languages[i].quality > languages[j].quality
+	})
+
+	result := make([]string, len(languages))
+
+	for i, language := range languages {
+		result[i] = language.lang
+	}
+
+	return result
+}
+
+func normalizeLanguage(lang string) string {
+	return strings.ToLower(strings.ReplaceAll(strings.TrimSpace(lang), "_", "-"))
+}
+
+// localizedError is a error which message is translated when rendered
+type localizedError struct {
+	key  string
+	args []interface{}
+}
+
+// LocalizedError create a error which message is a key of DefaultCatalog, translated by ErrorCtx.
+// When it is wrapped, just its part of message is translated.
+func LocalizedError(key string, args ...interface{}) error {
+	return &localizedError{key: key, args: args}
+}
+
+func (l *localizedError) Error() string {
+	return T(context.Background(), l.key, l.args...)
+}
+
+// Message send a translated message to respond json, like {"message": "product created"}
+func Message(ctx context.Context, w http.ResponseWriter, key string, code int, args ...interface{}) (int, error) {
+	return newCall(ctx).marshalled(w, map[string]string{"message": T(ctx, key, args...)}, code)
+}
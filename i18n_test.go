@@ -0,0 +1,155 @@
+package rest_test
+
+import (
+	"context"
+	"fmt"
+	"github.com/edermanoel94/rest-go"
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestCatalog(t *testing.T) {
+
+	catalog := rest.NewCatalog("en-US")
+
+	catalog.Set("en-US", "hello", "hello %s")
+	catalog.Set("pt", "hello", "olá %s")
+	catalog.SetPlural("en-US", "items", "%d item", "%d items")
+	catalog.SetPlural("pt-BR", "items", "%d item", "%d itens")
+	catalog.SetPluralRule("pt", func(n int) bool { return n == 0 || n == 1 })
+
+	testCases := []struct {
+		description string
+		lang        string
+		key         string
+		args        []interface{}
+		expected    string
+	}{
+		{"should translate to requested language", "en-US", "hello", []interface{}{"eder"}, "hello eder"},
+		{"should look at base language", "pt-BR", "hello", []interface{}{"eder"}, "olá eder"},
+		{"should look at fallback language", "es", "hello", []interface{}{"eder"}, "hello eder"},
+		{"should return key when message don't exist", "en-US", "bye", nil, "bye"},
+		{"should use singular form", "en-US", "items", []interface{}{1}, "1 item"},
+		{"should use plural form", "en-US", "items", []interface{}{0}, "0 items"},
+		{"should use plural rule of language", "pt-BR", "items", []interface{}{0}, "0 item"},
+	}
+
+	for _, tc := range testCases {
+
+		t.Run(tc.description, func(t *testing.T) {
+			assert.Equal(t, tc.expected, catalog.Translate(tc.lang, tc.key, tc.args...))
+		})
+	}
+
+	assert.Equal(t, []string{"en-us", "pt", "pt-br"}, catalog.Languages())
+}
+
+func TestNegotiateLanguage(t *testing.T) {
+
+	testCases := []struct {
+		description    string
+		acceptLanguage string
+		expected       string
+	}{
+		{"should match exact language", "pt-BR", "pt-BR"},
+		{"should respect quality", "en;q=0.5, pt-BR;q=0.9", "pt-BR"},
+		{"should match base language", "en-GB", "en-US"},
+		{"should return first supported when nothing match", "fr", "en-US"},
+		{"should return first supported without header", "", "en-US"},
+	}
+
+	for _, tc := range testCases {
+
+		t.Run(tc.description, func(t *testing.T) {
+
+			request := httptest.NewRequest(http.MethodGet, "/", nil)
+			request.Header.Set("Accept-Language", tc.acceptLanguage)
+
+			assert.Equal(t, tc.expected, rest.NegotiateLanguage(request, "en-US", "pt-BR"))
+		})
+	}
+}
+
+func TestLocalize(t *testing.T) {
+
+	rest.DefaultCatalog.Set("en", "product.created", "product created")
+	rest.DefaultCatalog.Set("pt-BR", "product.created", "produto criado")
+	rest.DefaultCatalog.Set("en", "product.not_found", "product %s not found")
+	rest.DefaultCatalog.Set("pt-BR", "product.not_found", "produto %s não encontrado")
+
+	t.Run("should send a translated success message", func(t *testing.T) {
+
+		handler := rest.Localize("en", "pt-BR")(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			rest.Message(r.Context(), w, "product.created", http.StatusCreated)
+		}))
+
+		request := httptest.NewRequest(http.MethodPost, "/", nil)
+		request.Header.Set("Accept-Language", "pt-BR,pt;q=0.9")
+
+		recorder := httptest.NewRecorder()
+
+		handler.ServeHTTP(recorder, request)
+
+		assert.Equal(t, http.StatusCreated, recorder.Code)
+		assert.Equal(t, "pt-BR", recorder.Header().Get("Content-Language"))
+		assert.Equal(t, `{"message":"produto criado"}`, recorder.Body.String())
+	})
+
+	t.Run("should send a translated error", func(t *testing.T) {
+
+		ctx := rest.WithLanguage(context.Background(), "pt-BR")
+
+		recorder := httptest.NewRecorder()
+
+		rest.ErrorCtx(ctx, recorder, rest.LocalizedError("product.not_found", "tv"), http.StatusNotFound)
+
+		assert.Equal(t, http.StatusNotFound, recorder.Code)
+		assert.Equal(t, `{"message":"produto tv não encontrado"}`, recorder.Body.String())
+	})
+
+	t.Run("should translate just the localized part of error", func(t *testing.T) {
+
+		ctx := rest.WithLanguage(context.Background(), "pt-BR")
+
+		recorder := httptest.NewRecorder()
+
+		err := &rest.UnavailableError{Err: fmt.Errorf("checkout: %w", rest.LocalizedError("product.not_found", "tv")), RetryAfter: 2 * time.Second}
+
+		rest.ErrorCtx(ctx, recorder, err, 0)
+
+		assert.Equal(t, http.StatusServiceUnavailable, recorder.Code)
+		assert.Equal(t, "2", recorder.Header().Get("Retry-After"))
+		assert.Equal(t, `{"message":"checkout: produto tv não encontrado","retry_after":2}`, recorder.Body.String())
+	})
+
+	t.Run("should translate messages of validation", func(t *testing.T) {
+
+		rest.DefaultCatalog.Set("pt-BR", "validation.min", "%s deve ter no mínimo %s")
+
+		ctx := rest.WithLanguage(context.Background(), "pt-BR")
+
+		recorder := httptest.NewRecorder()
+
+		err := &rest.ValidationError{Fields: []rest.FieldError{
+			{Name: "name", Rule: "min", Message: "name must be at least 3", Args: []interface{}{"3"}},
+			{Name: "email", Rule: "email", Message: "email must be a valid email"},
+		}}
+
+		rest.ErrorCtx(ctx, recorder, err, 0)
+
+		assert.Equal(t, http.StatusUnprocessableEntity, recorder.Code)
+		assert.Contains(t, recorder.Body.String(), `"message":"validation failed: name deve ter no mínimo 3, email must be a valid email"`)
+		assert.Contains(t, recorder.Body.String(), `{"name":"name","rule":"min","message":"name deve ter no mínimo 3"}`)
+		assert.Contains(t, recorder.Body.String(), `{"name":"email","rule":"email","message":"email must be a valid email"}`)
+	})
+
+	t.Run("should use fallback language on error message", func(t *testing.T) {
+
+		err := rest.LocalizedError("product.not_found", "tv")
+
+		assert.Equal(t, "product tv not found", err.Error())
+	})
+}
@@ -0,0 +1,347 @@
+package rest
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"log"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// IdempotencyKeyHeader is the header which carry the key of a request, sent by clients on retries
+const IdempotencyKeyHeader = "Idempotency-Key"
+
+// IdempotentReplayedHeader is sent on responses replayed by Idempotency
+const IdempotentReplayedHeader = "Idempotent-Replayed"
+
+// DefaultIdempotencyTTL is how long the responses are replayed when IdempotencyOptions.TTL is not set
+const DefaultIdempotencyTTL = 24 * time.Hour
+
+var (
+	ErrIdempotencyInProgress = errors.New("a request with the same idempotency key is in progress")
+	ErrIdempotencyKeyMissing = errors.New("idempotency key is required")
+)
+
+// StoredResponse is a response captured by Idempotency
+type StoredResponse struct {
+	Status int
+	Header http.Header
+	Body   []byte
+}
+
+// IdempotencyStore keep the responses of Idempotency, stores shared by many servers, like redis,
+// must reserve a key atomically
+type IdempotencyStore interface {
+	// Get return the response stored for key, false when there is none
+	Get(ctx context.Context, key string) (*StoredResponse, bool, error)
+	// Reserve mark key as in progress until ttl, reporting false when key is already reserved or stored
+	Reserve(ctx context.Context, key string, ttl time.Duration) (bool, error)
+	// Set store the response of key until ttl, replacing the reservation
+	Set(ctx context.Context, key string, response *StoredResponse, ttl time.Duration) error
+	// Release drop the reservation of key, so the request can be retried
+	Release(ctx context.Context, key string) error
+}
+
+// IdempotencyOptions configure the Idempotency middleware
+type IdempotencyOptions struct {
+	// Store keep the responses, a memory store is used when nil
+	Store IdempotencyStore
+	// TTL is how long a response is replayed, DefaultIdempotencyTTL when is 0
+	TTL time.Duration
+	// Required respond 400 for unsafe requests without key
+	Required bool
+	// Scope separate the keys of clients, like the id of user, the keys are scoped by method and path too
+	Scope func(r *http.Request) string
+}
+
+// Idempotency is a middleware which capture the status, headers and body of unsafe requests, like POST,
+// carrying a Idempotency-Key and replay them on retries with the same key until the TTL, with the header
+// Idempotent-Replayed: true. A retry while the first request is in progress respond 409. Server errors
+// are not stored, so the request can be retried.
+func Idempotency(opts IdempotencyOptions) func(http.Handler) http.Handler {
+
+	if opts.Store == nil {
+		opts.Store = NewMemoryIdempotencyStore()
+	}
+
+	if opts.TTL == 0 {
+		opts.TTL = DefaultIdempotencyTTL
+	}
+
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+
+			if isSafeMethod(r.Method) {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			ctx := r.Context()
+
+			key := r.Header.Get(IdempotencyKeyHeader)
+
+			if key == "" {
+				if opts.Required {
+					newCall(ctx).fail(w, ErrIdempotencyKeyMissing, http.StatusBadRequest)
+					return
+				}
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			key = r.Method + " " + r.URL.Path + " " + key
+
+			if opts.Scope != nil {
+				key = opts.Scope(r) + " " + key
+			}
+
+			if stored, ok, err := opts.Store.Get(ctx, key); err != nil {
+				newCall(ctx).fail(w, err, http.StatusInternalServerError)
+				return
+			} else if ok {
+				replay(w, stored)
+				return
+			}
+
+			reserved, err := opts.Store.Reserve(ctx, key, opts.TTL)
+
+			if err != nil {
+				newCall(ctx).fail(w, err, http.StatusInternalServerError)
+				return
+			}
+
+			if !reserved {
+				newCall(ctx).fail(w, ErrIdempotencyInProgress, http.StatusConflict)
+				return
+			}
+
+			rec := &idempotencyRecorder{responseWriter: newResponseWriter(w)}
+
+			// the key must be released or stored even when the client is gone
+			storeCtx := withoutCancel{ctx}
+
+			defer func() {
+
+				// a panic or a server error is not replayed, the client can retry
+				if recovered := recover(); recovered != nil {
+					opts.Store.Release(storeCtx, key)
+					panic(recovered)
+				}
+
+				if rec.status >= http.StatusInternalServerError {
+					opts.Store.Release(storeCtx, key)
+					return
+				}
+
+				if !rec.wroteHeader {
+					rec.header = rec.Header().Clone()
+				}
+
+				response := &StoredResponse{Status: rec.status, Header: rec.header, Body: rec.body.Bytes()}
+
+				if err := opts.Store.Set(storeCtx, key, response, opts.TTL); err != nil {
+					log.Printf("rest: couldn't store response of idempotency key %s: %v", key, err)
+				}
+			}()
+
+			next.ServeHTTP(expose(rec, w), r)
+		})
+	}
+}
+
+func isSafeMethod(method string) bool {
+	return method == http.MethodGet || method == http.MethodHead || method == http.MethodOptions || method == http.MethodTrace
+}
+
+// replay write a stored response
+func replay(w http.ResponseWriter, stored *StoredResponse) {
+
+	header := w.Header()
+
+	for key, values := range stored.Header {
+		header[key] = values
+	}
+
+	header.Set(IdempotentReplayedHeader, "true")
+
+	w.WriteHeader(stored.Status)
+	w.Write(stored.Body)
+}
+
+// idempotencyRecorder capture the response written, with the headers sent with the status
+type idempotencyRecorder struct {
+	*responseWriter
+	header http.Header
+	body   bytes.Buffer
+}
+
+func (rec *idempotencyRecorder) WriteHeader(code int) {
+	if !rec.wroteHeader {
+		rec.header = rec.Header().Clone()
+	}
+	rec.responseWriter.WriteHeader(code)
+}
+
+func (rec *idempotencyRecorder) Write(b []byte) (int, error) {
+	if !rec.wroteHeader {
+		rec.WriteHeader(http.StatusOK)
+	}
+	n, err := rec.responseWriter.Write(b)
+	rec.body.Write(b[:n])
+	return n, err
+}
+
+// ReadFrom copy through Write, so the body is captured
+func (rec *idempotencyRecorder) ReadFrom(r io.Reader) (int64, error) {
+	return io.Copy(writerOnly{rec}, r)
+}
+
+// Flush send the status before flushing, so the headers are captured
+func (rec *idempotencyRecorder) Flush() {
+	if !rec.wroteHeader {
+		rec.WriteHeader(http.StatusOK)
+	}
+	rec.responseWriter.Flush()
+}
+
+// withoutCancel keep the values of a context, without its deadline and cancellation
+type withoutCancel struct {
+	context.Context
+}
+
+func (withoutCancel) Deadline() (time.Time, bool) {
+	return time.Time{}, false
+}
+
+func (withoutCancel) Done() <-chan struct{} {
+	return nil
+}
+
+func (withoutCancel) Err() error {
+	return nil
+}
+
+// minSweep is the number of entries of MemoryIdempotencyStore before the first sweep
+const minSweep = 64
+
+// MemoryIdempotencyStore is a IdempotencyStore on memory, for a single server. The expired entries
+// are dropped when their key is used again, and swept on Reserve when the entries doubled since
+// the last sweep.
+type MemoryIdempotencyStore struct {
+	mu      sync.Mutex
+	entries map[string]*idempotencyEntry
+	// sweepAt is the number of entries which start the next sweep
+	sweepAt int
+}
+
+type idempotencyEntry struct {
+	// response is nil while the key is reserved
+	response *StoredResponse
+	expires  time.Time
+}
+
+// NewMemoryIdempotencyStore create a empty MemoryIdempotencyStore
+func NewMemoryIdempotencyStore() *MemoryIdempotencyStore {
+	return &MemoryIdempotencyStore{entries: make(map[string]*idempotencyEntry), sweepAt: minSweep}
+}
+
+// Len return the number of entries, counting the expired not dropped yet
+func (m *MemoryIdempotencyStore) Len() int {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return len(m.entries)
+}
+
+// Get return the response stored for key
+func (m *MemoryIdempotencyStore) Get(ctx context.Context, key string) (*StoredResponse, bool, error) {
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	entry, ok := m.entry(key)
+
+	if !ok || entry.response == nil {
+		return nil, false, nil
+	}
+
+	return entry.response, true, nil
+}
+
+// Reserve mark key as in progress, when there is no entry for key
+func (m *MemoryIdempotencyStore) Reserve(ctx context.Context, key string, ttl time.Duration) (bool, error) {
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if _, ok := m.entry(key); ok {
+		return false, nil
+	}
+
+	if len(m.entries) >= m.sweepAt {
+		m.sweep()
+	}
+
+	m.entries[key] = &idempotencyEntry{expires: time.Now().Add(ttl)}
+
+	return true, nil
+}
+
+// Set store the response of key
+func (m *MemoryIdempotencyStore) Set(ctx context.Context, key string, response *StoredResponse, ttl time.Duration) error {
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.entries[key] = &idempotencyEntry{response: response, expires: time.Now().Add(ttl)}
+
+	return nil
+}
+
+// Release drop the entry of key
+func (m *MemoryIdempotencyStore) Release(ctx context.Context, key string) error {
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	delete(m.entries, key)
+
+	return nil
+}
+
+// sweep drop every expired entry
+func (m *MemoryIdempotencyStore) sweep() {
+
+	now := time.Now()
+
+	for key, entry := range m.entries {
+		if now.After(entry.expires) {
+			delete(m.entries, key)
+		}
+	}
+
+	m.sweepAt = 2 * len(m.entries)
+
+	if m.sweepAt < minSweep {
+		m.sweepAt = minSweep
+	}
+}
+
+// entry return the entry of key which is not expired, dropping it when expired
+func (m *MemoryIdempotencyStore) entry(key string) (*idempotencyEntry, bool) {
+
+	entry, ok := m.entries[key]
+
+	if !ok {
+		return nil, false
+	}
+
+	if time.Now().After(entry.expires) {
+		delete(m.entries, key)
+		return nil, false
+	}
+
+	return entry, true
+}
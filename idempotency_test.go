@@ -0,0 +1,183 @@
+package rest_test
+
+import (
+	"context"
+	"fmt"
+	"github.com/edermanoel94/rest-go"
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestIdempotency(t *testing.T) {
+
+	newHandler := func(status int) (http.Handler, *int) {
+
+		calls := 0
+
+		handler := rest.Idempotency(rest.IdempotencyOptions{})(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			calls++
+			w.Header().Set("X-Call", strings.Repeat("a", calls))
+			rest.Marshalled(w, map[string]int{"id": calls}, status)
+		}))
+
+		return handler, &calls
+	}
+
+	send := func(handler http.Handler, method, key string) *httptest.ResponseRecorder {
+
+		request := httptest.NewRequest(method, "/payments", nil)
+
+		if key != "" {
+			request.Header.Set("Idempotency-Key", key)
+		}
+
+		recorder := httptest.NewRecorder()
+
+		handler.ServeHTTP(recorder, request)
+
+		return recorder
+	}
+
+	t.Run("should replay response of same key", func(t *testing.T) {
+
+		handler, calls := newHandler(http.StatusCreated)
+
+		first := send(handler, http.MethodPost, "abc")
+		second := send(handler, http.MethodPost, "abc")
+
+		assert.Equal(t, 1, *calls)
+		assert.Equal(t, http.StatusCreated, second.Code)
+		assert.Equal(t, first.Body.String(), second.Body.String())
+		assert.Equal(t, "a", second.Header().Get("X-Call"))
+		assert.Equal(t, "application/json", second.Header().Get("Content-Type"))
+		assert.Equal(t, "true", second.Header().Get("Idempotent-Replayed"))
+		assert.Empty(t, first.Header().Get("Idempotent-Replayed"))
+	})
+
+	testCases := []struct {
+		description string
+		status      int
+		method      string
+		keys        []string
+		calls       int
+	}{
+		{"should call handler for other keys", http.StatusCreated, http.MethodPost, []string{"abc", "def"}, 2},
+		{"should call handler without key", http.StatusCreated, http.MethodPost, []string{"", ""}, 2},
+		{"should not store server errors", http.StatusInternalServerError, http.MethodPost, []string{"abc", "abc"}, 2},
+		{"should not store safe methods", http.StatusOK, http.MethodGet, []string{"abc", "abc"}, 2},
+		{"should store client errors", http.StatusUnprocessableEntity, http.MethodPost, []string{"abc", "abc"}, 1},
+	}
+
+	for _, tc := range testCases {
+
+		t.Run(tc.description, func(t *testing.T) {
+
+			handler, calls := newHandler(tc.status)
+
+			for _, key := range tc.keys {
+				recorder := send(handler, tc.method, key)
+				assert.Equal(t, tc.status, recorder.Code)
+			}
+
+			assert.Equal(t, tc.calls, *calls)
+		})
+	}
+
+	t.Run("should respond conflict while the first request is in progress", func(t *testing.T) {
+
+		var retry *httptest.ResponseRecorder
+
+		var handler http.Handler
+
+		handler = rest.Idempotency(rest.IdempotencyOptions{})(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if retry == nil {
+				retry = send(handler, http.MethodPost, "abc")
+			}
+			rest.NoContent(w)
+		}))
+
+		recorder := send(handler, http.MethodPost, "abc")
+
+		assert.Equal(t, http.StatusNoContent, recorder.Code)
+		assert.Equal(t, http.StatusConflict, retry.Code)
+		assert.Equal(t, `{"message":"a request with the same idempotency key is in progress"}`, retry.Body.String())
+	})
+
+	t.Run("should release key when client is gone", func(t *testing.T) {
+
+		store := &contextStore{MemoryIdempotencyStore: rest.NewMemoryIdempotencyStore()}
+
+		ctx, cancel := context.WithCancel(context.Background())
+
+		handler := rest.Idempotency(rest.IdempotencyOptions{Store: store})(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			cancel()
+			rest.Marshalled(w, map[string]string{"message": "database is down"}, http.StatusInternalServerError)
+		}))
+
+		request := httptest.NewRequest(http.MethodPost, "/payments", nil).WithContext(ctx)
+		request.Header.Set("Idempotency-Key", "abc")
+
+		handler.ServeHTTP(httptest.NewRecorder(), request)
+
+		reserved, err := store.Reserve(context.Background(), "POST /payments abc", time.Minute)
+
+		assert.NoError(t, err)
+		assert.True(t, reserved)
+	})
+
+	t.Run("should require key", func(t *testing.T) {
+
+		handler := rest.Idempotency(rest.IdempotencyOptions{Required: true})(http.NotFoundHandler())
+
+		recorder := send(handler, http.MethodPost, "")
+
+		assert.Equal(t, http.StatusBadRequest, recorder.Code)
+		assert.Equal(t, `{"message":"idempotency key is required"}`, recorder.Body.String())
+	})
+}
+
+// contextStore fail like a remote store when the context is done
+type contextStore struct {
+	*rest.MemoryIdempotencyStore
+}
+
+func (s *contextStore) Release(ctx context.Context, key string) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	return s.MemoryIdempotencyStore.Release(ctx, key)
+}
+
+func TestMemoryIdempotencyStore(t *testing.T) {
+
+	t.Run("should drop expired entries", func(t *testing.T) {
+
+		store := rest.NewMemoryIdempotencyStore()
+
+		store.Set(context.Background(), "abc", &rest.StoredResponse{Status: http.StatusOK}, -time.Second)
+
+		_, ok, err := store.Get(context.Background(), "abc")
+
+		assert.NoError(t, err)
+		assert.False(t, ok)
+
+		reserved, err := store.Reserve(context.Background(), "abc", time.Minute)
+
+		assert.NoError(t, err)
+		assert.True(t, reserved)
+	})
+	t.Run("should sweep expired entries of other keys", func(t *testing.T) {
+
+		store := rest.NewMemoryIdempotencyStore()
+
+		for i := 0; i < 1000; i++ {
+			store.Reserve(context.Background(), fmt.Sprintf("key-%d", i), -time.Second)
+		}
+
+		assert.True(t, store.Len() < 100, "store keep %d entries", store.Len())
+	})
+}
@@ -0,0 +1,77 @@
+package rest
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"os"
+	"strconv"
+	"sync"
+)
+
+// PrettyEnv is the environment variable which enable indented json on every response, like REST_PRETTY=true
+const PrettyEnv = "REST_PRETTY"
+
+const defaultIndent = "  "
+
+var (
+	indentMu     sync.RWMutex
+	globalIndent = indentFromEnv()
+)
+
+func indentFromEnv() string {
+	if pretty, _ := strconv.ParseBool(os.Getenv(PrettyEnv)); pretty {
+		return defaultIndent
+	}
+	return ""
+}
+
+// SetIndent indent every json response, a empty string send minified json
+func SetIndent(indent string) {
+	indentMu.Lock()
+	defer indentMu.Unlock()
+	globalIndent = indent
+}
+
+// Indent send a indented json on response
+func Indent(indent string) Option {
+	return func(o *options) {
+		o.indent = &indent
+	}
+}
+
+// Pretty send a indented json when the request have the query parameter pretty=true
+func Pretty(r *http.Request) Option {
+	return func(o *options) {
+		if pretty, _ := strconv.ParseBool(r.URL.Query().Get("pretty")); pretty {
+			indent := defaultIndent
+			o.indent = &indent
+		}
+	}
+}
+
+// indentJson indent body with the indent of options, or the global one
+func (o *options) indentJson(body []byte) []byte {
+
+	indent := ""
+
+	if o.indent != nil {
+		indent = *o.indent
+	} else {
+		indentMu.RLock()
+		indent = globalIndent
+		indentMu.RUnlock()
+	}
+
+	if indent == "" {
+		return body
+	}
+
+	buf := &bytes.Buffer{}
+
+	if err := json.Indent(buf, body, "", indent); err != nil {
+		return body
+	}
+
+	return buf.Bytes()
+}
@@ -0,0 +1,76 @@
+package rest_test
+
+import (
+	"github.com/edermanoel94/rest-go"
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestIndent(t *testing.T) {
+
+	t.Run("should send minified json by default", func(t *testing.T) {
+
+		recorder := httptest.NewRecorder()
+
+		rest.Marshalled(recorder, map[string]int{"id": 1}, http.StatusOK)
+
+		assert.Equal(t, `{"id":1}`, recorder.Body.String())
+	})
+
+	t.Run("should indent json with option", func(t *testing.T) {
+
+		recorder := httptest.NewRecorder()
+
+		rest.Marshalled(recorder, map[string]int{"id": 1}, http.StatusOK, rest.Indent("\t"))
+
+		assert.Equal(t, "{\n\t\"id\": 1\n}", recorder.Body.String())
+	})
+
+	t.Run("should indent every json with global indent", func(t *testing.T) {
+
+		rest.SetIndent("  ")
+		defer rest.SetIndent("")
+
+		recorder := httptest.NewRecorder()
+
+		rest.Response(recorder, []byte(`{"id":1}`), http.StatusOK)
+
+		assert.Equal(t, "{\n  \"id\": 1\n}", recorder.Body.String())
+	})
+
+	t.Run("should override global indent with option", func(t *testing.T) {
+
+		rest.SetIndent("  ")
+		defer rest.SetIndent("")
+
+		recorder := httptest.NewRecorder()
+
+		rest.Response(recorder, []byte(`{"id":1}`), http.StatusOK, rest.Indent(""))
+
+		assert.Equal(t, `{"id":1}`, recorder.Body.String())
+	})
+
+	t.Run("should indent when request ask for pretty", func(t *testing.T) {
+
+		request := httptest.NewRequest(http.MethodGet, "/products?pretty=true", nil)
+
+		recorder := httptest.NewRecorder()
+
+		rest.Marshalled(recorder, map[string]int{"id": 1}, http.StatusOK, rest.Pretty(request))
+
+		assert.Equal(t, "{\n  \"id\": 1\n}", recorder.Body.String())
+	})
+
+	t.Run("should not indent when request don't ask for pretty", func(t *testing.T) {
+
+		request := httptest.NewRequest(http.MethodGet, "/products", nil)
+
+		recorder := httptest.NewRecorder()
+
+		rest.Marshalled(recorder, map[string]int{"id": 1}, http.StatusOK, rest.Pretty(request))
+
+		assert.Equal(t, `{"id":1}`, recorder.Body.String())
+	})
+}
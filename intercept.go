@@ -0,0 +1,83 @@
+package rest
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"sync"
+)
+
+// Reply is a response about to be written by a helper of package
+type Reply struct {
+	Context     context.Context
+	Status      int
+	ContentType string
+	// Body is a copy owned by the interceptors, it can be kept after the reply is written
+	Body []byte
+	// Err is the error sent by Error, nil on success
+	Err error
+}
+
+// WriterFunc write a reply, returning the bytes written
+type WriterFunc func(w http.ResponseWriter, reply *Reply) (int, error)
+
+// Interceptor wrap the writing of replies, can change status, headers and body before calling next,
+// or don't call next to send nothing
+type Interceptor func(next WriterFunc) WriterFunc
+
+var (
+	interceptorsMu sync.RWMutex
+	interceptors   []Interceptor
+)
+
+// Use add interceptors to every reply written by the package, the first added is the first called
+func Use(interceptor ...Interceptor) {
+	interceptorsMu.Lock()
+	defer interceptorsMu.Unlock()
+	interceptors = append(interceptors, interceptor...)
+}
+
+// ResetInterceptors remove every interceptor added by Use
+func ResetInterceptors() {
+	interceptorsMu.Lock()
+	defer interceptorsMu.Unlock()
+	interceptors = nil
+}
+
+// writeReply is the last WriterFunc, which send the reply to writer
+func writeReply(w http.ResponseWriter, reply *Reply) (int, error) {
+
+	w.Header().Set(contentType, reply.ContentType)
+	w.WriteHeader(reply.Status)
+
+	n, err := w.Write(reply.Body)
+
+	if err == nil && n < len(reply.Body) {
+		err = io.ErrShortWrite
+	}
+
+	return n, err
+}
+
+// intercepted return last wrapped by interceptors
+func intercepted(last WriterFunc) WriterFunc {
+
+	interceptorsMu.RLock()
+	defer interceptorsMu.RUnlock()
+
+	writer := last
+
+	if len(interceptors) == 0 {
+		return writer
+	}
+
+	for i := len(interceptors) - 1; i >= 0; i-- {
+		writer = interceptors[i](writer)
+	}
+
+	// the body may be a pooled buffer, which is reused once the reply is written
+	return func(w http.ResponseWriter, reply *Reply) (int, error) {
+		reply.Body = append([]byte(nil), reply.Body...)
+		return writer(w, reply)
+	}
+}
@@ -0,0 +1,119 @@
+package rest_test
+
+import (
+	"errors"
+	"github.com/edermanoel94/rest-go"
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestUse(t *testing.T) {
+
+	t.Run("should change body and headers before writing", func(t *testing.T) {
+
+		rest.Use(func(next rest.WriterFunc) rest.WriterFunc {
+			return func(w http.ResponseWriter, reply *rest.Reply) (int, error) {
+				if reply.Err == nil {
+					reply.Body = append(append([]byte(`{"data":`), reply.Body...), '}')
+				}
+				w.Header().Set("X-Enveloped", "true")
+				return next(w, reply)
+			}
+		})
+		defer rest.ResetInterceptors()
+
+		recorder := httptest.NewRecorder()
+
+		n, err := rest.Marshalled(recorder, []int{1}, http.StatusOK)
+
+		assert.NoError(t, err)
+		assert.Equal(t, recorder.Body.Len(), n)
+		assert.Equal(t, `{"data":[1]}`, recorder.Body.String())
+		assert.Equal(t, "true", recorder.Header().Get("X-Enveloped"))
+	})
+
+	t.Run("should call interceptors on order", func(t *testing.T) {
+
+		calls := make([]string, 0)
+
+		record := func(name string) rest.Interceptor {
+			return func(next rest.WriterFunc) rest.WriterFunc {
+				return func(w http.ResponseWriter, reply *rest.Reply) (int, error) {
+					calls = append(calls, name)
+					return next(w, reply)
+				}
+			}
+		}
+
+		rest.Use(record("first"), record("second"))
+		defer rest.ResetInterceptors()
+
+		rest.Error(httptest.NewRecorder(), errors.New("not found"), http.StatusNotFound)
+
+		assert.Equal(t, []string{"first", "second"}, calls)
+	})
+
+	t.Run("should change status", func(t *testing.T) {
+
+		var events []rest.Event
+
+		rest.OnWrite(func(event rest.Event) {
+			events = append(events, event)
+		})
+		defer rest.ResetWriteHooks()
+
+		rest.Use(func(next rest.WriterFunc) rest.WriterFunc {
+			return func(w http.ResponseWriter, reply *rest.Reply) (int, error) {
+				if reply.Status == http.StatusNotFound {
+					reply.Status = http.StatusGone
+				}
+				return next(w, reply)
+			}
+		})
+		defer rest.ResetInterceptors()
+
+		recorder := httptest.NewRecorder()
+
+		rest.Error(recorder, errors.New("removed"), http.StatusNotFound)
+
+		assert.Equal(t, http.StatusGone, recorder.Code)
+		assert.Equal(t, http.StatusGone, events[0].Status)
+	})
+
+	t.Run("should send nothing when next is not called", func(t *testing.T) {
+
+		rest.Use(func(next rest.WriterFunc) rest.WriterFunc {
+			return func(w http.ResponseWriter, reply *rest.Reply) (int, error) {
+				return 0, nil
+			}
+		})
+		defer rest.ResetInterceptors()
+
+		recorder := httptest.NewRecorder()
+
+		rest.Marshalled(recorder, []int{1}, http.StatusOK)
+
+		assert.Equal(t, 0, recorder.Body.Len())
+	})
+
+	t.Run("should keep the body after the reply is written", func(t *testing.T) {
+
+		var bodies [][]byte
+
+		rest.Use(func(next rest.WriterFunc) rest.WriterFunc {
+			return func(w http.ResponseWriter, reply *rest.Reply) (int, error) {
+				bodies = append(bodies, reply.Body)
+				return next(w, reply)
+			}
+		})
+		defer rest.ResetInterceptors()
+
+		rest.Marshalled(httptest.NewRecorder(), map[string]string{"name": "first"}, http.StatusOK)
+		rest.Marshalled(httptest.NewRecorder(), map[string]string{"name": "second"}, http.StatusOK)
+
+		assert.Equal(t, `{"name":"first"}`, string(bodies[0]))
+		assert.Equal(t, `{"name":"second"}`, string(bodies[1]))
+	})
+}
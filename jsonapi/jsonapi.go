@@ -0,0 +1,454 @@
+// Package jsonapi send resources on JSON:API format (https://jsonapi.org), driven by struct tags:
+//
+//	type product struct {
+//		ID       int       `jsonapi:"primary,products"`
+//		Name     string    `jsonapi:"attr,name"`
+//		Price    float64   `jsonapi:"attr,price,omitempty"`
+//		Category *category `jsonapi:"relation,category"`
+//	}
+//
+// Related resources are sent on relationships as resource identifiers and on included.
+package jsonapi
+
+import (
+	"errors"
+	"fmt"
+	"github.com/edermanoel94/rest-go"
+	"net/http"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+// MediaType is the content type of JSON:API documents
+const MediaType = "application/vnd.api+json"
+
+// Document is the top level of a JSON:API response
+type Document struct {
+	Data     interface{}            `json:"data,omitempty"`
+	Errors   []*Error               `json:"errors,omitempty"`
+	Included []*Resource            `json:"included,omitempty"`
+	Meta     map[string]interface{} `json:"meta,omitempty"`
+	Links    map[string]string      `json:"links,omitempty"`
+}
+
+// Resource is a resource object, with its attributes and relationships
+type Resource struct {
+	Type          string                   `json:"type"`
+	ID            string                   `json:"id"`
+	Attributes    map[string]interface{}   `json:"attributes,omitempty"`
+	Relationships map[string]*Relationship `json:"relationships,omitempty"`
+}
+
+// Identifier identify a related resource
+type Identifier struct {
+	Type string `json:"type"`
+	ID   string `json:"id"`
+}
+
+// Relationship have a *Identifier for to-one relations, a []*Identifier for to-many, or nil
+type Relationship struct {
+	Data interface{} `json:"data"`
+}
+
+// Error is a error object, sent on errors of document
+type Error struct {
+	Status string  `json:"status,omitempty"`
+	Code   string  `json:"code,omitempty"`
+	Title  string  `json:"title,omitempty"`
+	Detail string  `json:"detail,omitempty"`
+	Source *Source `json:"source,omitempty"`
+}
+
+// Source is the part of request which caused a error, like the pointer /data/attributes/name
+type Source struct {
+	Pointer   string `json:"pointer,omitempty"`
+	Parameter string `json:"parameter,omitempty"`
+}
+
+func (e *Error) Error() string {
+	if e.Detail != "" {
+		return e.Detail
+	}
+	return e.Title
+}
+
+// ErrNotResource is returned when a value have no field tagged jsonapi:"primary,type"
+var ErrNotResource = errors.New("jsonapi: value is not a resource")
+
+// Marshal create a document from a resource, a pointer to resource or a slice of them
+func Marshal(v interface{}) (*Document, error) {
+
+	m := &marshaler{included: map[Identifier]bool{}}
+
+	value := reflect.ValueOf(v)
+
+	for value.Kind() == reflect.Ptr || value.Kind() == reflect.Interface {
+		if value.IsNil() {
+			return &Document{Data: nil}, nil
+		}
+		value = value.Elem()
+	}
+
+	doc := &Document{}
+
+	switch value.Kind() {
+	case reflect.Slice, reflect.Array:
+
+		resources := make([]*Resource, 0, value.Len())
+
+		for i := 0; i < value.Len(); i++ {
+
+			resource, err := m.resource(value.Index(i))
+
+			if err != nil {
+				return nil, err
+			}
+
+			if resource != nil {
+				resources = append(resources, resource)
+			}
+		}
+
+		// the primary resources are not included again
+		for _, resource := range resources {
+			m.included[Identifier{resource.Type, resource.ID}] = true
+		}
+
+		doc.Data = resources
+	default:
+
+		resource, err := m.resource(value)
+
+		if err != nil {
+			return nil, err
+		}
+
+		m.included[Identifier{resource.Type, resource.ID}] = true
+
+		doc.Data = resource
+	}
+
+	if err := m.includeRelated(); err != nil {
+		return nil, err
+	}
+
+	doc.Included = m.resources
+
+	return doc, nil
+}
+
+// Marshalled send v as a JSON:API document, which is created by Marshal
+func Marshalled(w http.ResponseWriter, v interface{}, code int, opts ...rest.Option) (int, error) {
+
+	doc, err := Marshal(v)
+
+	if err != nil {
+		return Errors(w, http.StatusInternalServerError, &Error{Title: http.StatusText(http.StatusInternalServerError), Detail: err.Error()})
+	}
+
+	return Write(w, doc, code, opts...)
+}
+
+// Write send a document built by hand, with meta or links
+func Write(w http.ResponseWriter, doc *Document, code int, opts ...rest.Option) (int, error) {
+
+	if doc.Data == nil && len(doc.Errors) == 0 && doc.Meta == nil {
+		// a document must have data, errors or meta, a missing resource is data null
+		return rest.Response(w, []byte(`{"data":null}`), code, append(opts, rest.WithContentType(MediaType))...)
+	}
+
+	return rest.Marshalled(w, doc, code, append(opts, rest.WithContentType(MediaType))...)
+}
+
+// Errors send error objects with the status given, the status of errors is filled when empty
+func Errors(w http.ResponseWriter, code int, errs ...*Error) (int, error) {
+
+	for _, err := range errs {
+		if err.Status == "" {
+			err.Status = strconv.Itoa(code)
+		}
+	}
+
+	return rest.Marshalled(w, &Document{Errors: errs}, code, rest.WithContentType(MediaType))
+}
+
+// Fail send a error as a error object, a *Error is sent as is, and the status of a
+// rest.StatusCoder is used instead of code
+func Fail(w http.ResponseWriter, err error, code int) (int, error) {
+
+	var statusCoder rest.StatusCoder
+
+	if errors.As(err, &statusCoder) {
+		code = statusCoder.StatusCode()
+	}
+
+	if code == 0 {
+		code = http.StatusInternalServerError
+	}
+
+	var apiErr *Error
+
+	if errors.As(err, &apiErr) {
+		return Errors(w, code, apiErr)
+	}
+
+	return Errors(w, code, &Error{Title: http.StatusText(code), Detail: err.Error()})
+}
+
+type marshaler struct {
+	// included keep the resources already sent, by identifier
+	included  map[Identifier]bool
+	resources []*Resource
+	// pending are the related values found, not yet included
+	pending []reflect.Value
+}
+
+type tag struct {
+	kind      string
+	name      string
+	omitempty bool
+}
+
+// parseTag return the jsonapi tag of field, false when it has none, and a error when the tag has no name
+func parseTag(field reflect.StructField) (tag, bool, error) {
+
+	value, ok := field.Tag.Lookup("jsonapi")
+
+	if !ok || value == "-" {
+		return tag{}, false, nil
+	}
+
+	parts := strings.Split(value, ",")
+
+	t := tag{kind: parts[0]}
+
+	if len(parts) > 1 {
+		t.name = parts[1]
+	}
+
+	if t.name == "" {
+		return tag{}, false, fmt.Errorf("jsonapi: tag %s without name on field %s", t.kind, field.Name)
+	}
+
+	if len(parts) > 2 {
+		for _, option := range parts[2:] {
+			if option == "omitempty" {
+				t.omitempty = true
+			}
+		}
+	}
+
+	return t, true, nil
+}
+
+// resource create a resource object from a struct, nil pointers are skipped
+func (m *marshaler) resource(value reflect.Value) (*Resource, error) {
+
+	for value.Kind() == reflect.Ptr || value.Kind() == reflect.Interface {
+		if value.IsNil() {
+			return nil, nil
+		}
+		value = value.Elem()
+	}
+
+	if value.Kind() != reflect.Struct {
+		return nil, fmt.Errorf("%w: %s", ErrNotResource, value.Type())
+	}
+
+	resource := &Resource{}
+
+	primary := false
+
+	for i := 0; i < value.NumField(); i++ {
+
+		field := value.Type().Field(i)
+
+		t, ok, err := parseTag(field)
+
+		if err != nil {
+			return nil, err
+		}
+
+		if !ok || field.PkgPath != "" {
+			continue
+		}
+
+		fieldValue := value.Field(i)
+
+		switch t.kind {
+		case "primary":
+			resource.Type = t.name
+			resource.ID = formatID(fieldValue)
+			primary = true
+		case "attr":
+
+			if t.omitempty && isEmpty(fieldValue) {
+				continue
+			}
+
+			if resource.Attributes == nil {
+				resource.Attributes = map[string]interface{}{}
+			}
+
+			resource.Attributes[t.name] = fieldValue.Interface()
+		case "relation":
+
+			relationship, err := m.relationship(fieldValue)
+
+			if err != nil {
+				return nil, err
+			}
+
+			if t.omitempty && relationship.Data == nil {
+				continue
+			}
+
+			if resource.Relationships == nil {
+				resource.Relationships = map[string]*Relationship{}
+			}
+
+			resource.Relationships[t.name] = relationship
+		default:
+			return nil, fmt.Errorf("jsonapi: unknown tag %s on field %s", t.kind, field.Name)
+		}
+	}
+
+	if !primary {
+		return nil, fmt.Errorf("%w: %s", ErrNotResource, value.Type())
+	}
+
+	return resource, nil
+}
+
+// relationship create the identifiers of related values, keeping them to include
+func (m *marshaler) relationship(value reflect.Value) (*Relationship, error) {
+
+	if value.Kind() == reflect.Slice || value.Kind() == reflect.Array {
+
+		identifiers := make([]*Identifier, 0, value.Len())
+
+		for i := 0; i < value.Len(); i++ {
+
+			identifier, err := m.identifier(value.Index(i))
+
+			if err != nil {
+				return nil, err
+			}
+
+			if identifier != nil {
+				identifiers = append(identifiers, identifier)
+			}
+		}
+
+		return &Relationship{Data: identifiers}, nil
+	}
+
+	identifier, err := m.identifier(value)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if identifier == nil {
+		return &Relationship{}, nil
+	}
+
+	return &Relationship{Data: identifier}, nil
+}
+
+func (m *marshaler) identifier(value reflect.Value) (*Identifier, error) {
+
+	identifier, err := identify(value)
+
+	if err != nil || identifier == nil {
+		return nil, err
+	}
+
+	m.pending = append(m.pending, value)
+
+	return identifier, nil
+}
+
+// identify return the type and id of a resource, without walking its relations
+func identify(value reflect.Value) (*Identifier, error) {
+
+	for value.Kind() == reflect.Ptr || value.Kind() == reflect.Interface {
+		if value.IsNil() {
+			return nil, nil
+		}
+		value = value.Elem()
+	}
+
+	if value.Kind() == reflect.Struct {
+		for i := 0; i < value.NumField(); i++ {
+			t, ok, err := parseTag(value.Type().Field(i))
+			if err != nil {
+				return nil, err
+			}
+			if ok && t.kind == "primary" {
+				return &Identifier{Type: t.name, ID: formatID(value.Field(i))}, nil
+			}
+		}
+	}
+
+	return nil, fmt.Errorf("%w: %s", ErrNotResource, value.Type())
+}
+
+// includeRelated include every related value once, walking the relations of included too
+func (m *marshaler) includeRelated() error {
+
+	for len(m.pending) > 0 {
+
+		value := m.pending[0]
+		m.pending = m.pending[1:]
+
+		identifier, err := identify(value)
+
+		if err != nil {
+			return err
+		}
+
+		if m.included[*identifier] {
+			continue
+		}
+
+		m.included[*identifier] = true
+
+		resource, err := m.resource(value)
+
+		if err != nil {
+			return err
+		}
+
+		m.resources = append(m.resources, resource)
+	}
+
+	return nil
+}
+
+func formatID(value reflect.Value) string {
+
+	switch value.Kind() {
+	case reflect.String:
+		return value.String()
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return strconv.FormatInt(value.Int(), 10)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return strconv.FormatUint(value.Uint(), 10)
+	}
+
+	return fmt.Sprint(value.Interface())
+}
+
+func isEmpty(value reflect.Value) bool {
+
+	switch value.Kind() {
+	case reflect.Array, reflect.Map, reflect.Slice, reflect.String:
+		return value.Len() == 0
+	case reflect.Ptr, reflect.Interface:
+		return value.IsNil()
+	}
+
+	return value.IsZero()
+}
@@ -0,0 +1,206 @@
+package jsonapi_test
+
+import (
+	"errors"
+	"github.com/edermanoel94/rest-go"
+	"github.com/edermanoel94/rest-go/jsonapi"
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+type category struct {
+	ID     string    `jsonapi:"primary,categories"`
+	Name   string    `jsonapi:"attr,name"`
+	Parent *category `jsonapi:"relation,parent,omitempty"`
+}
+
+type tag struct {
+	ID   int    `jsonapi:"primary,tags"`
+	Name string `jsonapi:"attr,name"`
+}
+
+type product struct {
+	ID       int       `jsonapi:"primary,products"`
+	Name     string    `jsonapi:"attr,name"`
+	Price    float64   `jsonapi:"attr,price,omitempty"`
+	Category *category `jsonapi:"relation,category"`
+	Tags     []tag     `jsonapi:"relation,tags"`
+	internal string
+}
+
+func TestMarshalled(t *testing.T) {
+
+	electronics := &category{ID: "electronics", Name: "Electronics"}
+
+	t.Run("should send a resource with relationships and included", func(t *testing.T) {
+
+		recorder := httptest.NewRecorder()
+
+		jsonapi.Marshalled(recorder, &product{ID: 1, Name: "tv", Price: 10, Category: electronics, Tags: []tag{{ID: 2, Name: "home"}}}, http.StatusOK)
+
+		assert.Equal(t, http.StatusOK, recorder.Code)
+		assert.Equal(t, "application/vnd.api+json", recorder.Header().Get("Content-Type"))
+		assert.JSONEq(t, `{
+			"data": {
+				"type": "products", "id": "1",
+				"attributes": {"name": "tv", "price": 10},
+				"relationships": {
+					"category": {"data": {"type": "categories", "id": "electronics"}},
+					"tags": {"data": [{"type": "tags", "id": "2"}]}
+				}
+			},
+			"included": [
+				{"type": "categories", "id": "electronics", "attributes": {"name": "Electronics"}},
+				{"type": "tags", "id": "2", "attributes": {"name": "home"}}
+			]
+		}`, recorder.Body.String())
+	})
+
+	t.Run("should include a related resource once", func(t *testing.T) {
+
+		recorder := httptest.NewRecorder()
+
+		jsonapi.Marshalled(recorder, []product{{ID: 1, Name: "tv", Category: electronics}, {ID: 2, Name: "radio", Category: electronics}}, http.StatusOK)
+
+		assert.JSONEq(t, `{
+			"data": [
+				{"type": "products", "id": "1", "attributes": {"name": "tv"},
+					"relationships": {"category": {"data": {"type": "categories", "id": "electronics"}}, "tags": {"data": []}}},
+				{"type": "products", "id": "2", "attributes": {"name": "radio"},
+					"relationships": {"category": {"data": {"type": "categories", "id": "electronics"}}, "tags": {"data": []}}}
+			],
+			"included": [
+				{"type": "categories", "id": "electronics", "attributes": {"name": "Electronics"}}
+			]
+		}`, recorder.Body.String())
+	})
+
+	t.Run("should send null to empty relations", func(t *testing.T) {
+
+		recorder := httptest.NewRecorder()
+
+		jsonapi.Marshalled(recorder, product{ID: 1, Name: "tv"}, http.StatusOK)
+
+		assert.JSONEq(t, `{
+			"data": {"type": "products", "id": "1", "attributes": {"name": "tv"},
+				"relationships": {"category": {"data": null}, "tags": {"data": []}}}
+		}`, recorder.Body.String())
+	})
+
+	t.Run("should include relations of included resources without looping", func(t *testing.T) {
+
+		root := &category{ID: "root", Name: "Root"}
+		child := &category{ID: "child", Name: "Child", Parent: root}
+		root.Parent = child
+
+		recorder := httptest.NewRecorder()
+
+		jsonapi.Marshalled(recorder, child, http.StatusOK)
+
+		assert.JSONEq(t, `{
+			"data": {"type": "categories", "id": "child", "attributes": {"name": "Child"},
+				"relationships": {"parent": {"data": {"type": "categories", "id": "root"}}}},
+			"included": [
+				{"type": "categories", "id": "root", "attributes": {"name": "Root"},
+					"relationships": {"parent": {"data": {"type": "categories", "id": "child"}}}}
+			]
+		}`, recorder.Body.String())
+	})
+
+	t.Run("should send data null to a nil resource", func(t *testing.T) {
+
+		recorder := httptest.NewRecorder()
+
+		var missing *product
+
+		jsonapi.Marshalled(recorder, missing, http.StatusOK)
+
+		assert.Equal(t, `{"data":null}`, recorder.Body.String())
+	})
+
+	t.Run("should send a error when value is not a resource", func(t *testing.T) {
+
+		recorder := httptest.NewRecorder()
+
+		jsonapi.Marshalled(recorder, struct{ Name string }{"tv"}, http.StatusOK)
+
+		assert.Equal(t, http.StatusInternalServerError, recorder.Code)
+		assert.Contains(t, recorder.Body.String(), `"errors":[{"status":"500"`)
+	})
+
+	t.Run("should send a error when tag has no name", func(t *testing.T) {
+
+		for _, value := range []interface{}{
+			struct {
+				ID int `jsonapi:"primary"`
+			}{1},
+			struct {
+				ID   int    `jsonapi:"primary,products"`
+				Name string `jsonapi:"attr"`
+			}{1, "tv"},
+		} {
+
+			recorder := httptest.NewRecorder()
+
+			jsonapi.Marshalled(recorder, value, http.StatusOK)
+
+			assert.Equal(t, http.StatusInternalServerError, recorder.Code)
+			assert.Contains(t, recorder.Body.String(), "without name")
+		}
+	})
+}
+
+func TestWrite(t *testing.T) {
+
+	t.Run("should send meta and links of document", func(t *testing.T) {
+
+		doc, err := jsonapi.Marshal([]tag{{ID: 1, Name: "home"}})
+
+		assert.NoError(t, err)
+
+		doc.Meta = map[string]interface{}{"total": 1}
+		doc.Links = map[string]string{"self": "/tags"}
+
+		recorder := httptest.NewRecorder()
+
+		jsonapi.Write(recorder, doc, http.StatusOK)
+
+		assert.JSONEq(t, `{
+			"data": [{"type": "tags", "id": "1", "attributes": {"name": "home"}}],
+			"meta": {"total": 1},
+			"links": {"self": "/tags"}
+		}`, recorder.Body.String())
+	})
+}
+
+func TestFail(t *testing.T) {
+
+	testCases := []struct {
+		description string
+		err         error
+		code        int
+		expected    string
+	}{
+		{"should send a error object", errors.New("product not found"), http.StatusNotFound,
+			`{"errors":[{"status":"404","title":"Not Found","detail":"product not found"}]}`},
+		{"should send a api error as is", &jsonapi.Error{Code: "invalid_name", Detail: "name is required", Source: &jsonapi.Source{Pointer: "/data/attributes/name"}}, http.StatusUnprocessableEntity,
+			`{"errors":[{"status":"422","code":"invalid_name","detail":"name is required","source":{"pointer":"/data/attributes/name"}}]}`},
+		{"should send status of StatusCoder", &rest.BindError{Status: http.StatusRequestEntityTooLarge, Message: "too large"}, http.StatusBadRequest,
+			`{"errors":[{"status":"413","title":"Request Entity Too Large","detail":"too large"}]}`},
+	}
+
+	for _, tc := range testCases {
+
+		t.Run(tc.description, func(t *testing.T) {
+
+			recorder := httptest.NewRecorder()
+
+			jsonapi.Fail(recorder, tc.err, tc.code)
+
+			assert.Equal(t, "application/vnd.api+json", recorder.Header().Get("Content-Type"))
+			assert.JSONEq(t, tc.expected, recorder.Body.String())
+		})
+	}
+}
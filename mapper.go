@@ -0,0 +1,109 @@
+package rest
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"reflect"
+	"sync"
+)
+
+// ErrorMapper map errors to status codes, walking the wrap chain with errors.Is and errors.As
+type ErrorMapper struct {
+	mu       sync.RWMutex
+	mappings []errorMapping
+}
+
+type errorMapping struct {
+	sentinel  error
+	errorType reflect.Type
+	status    int
+}
+
+// DefaultErrorMapper is used by Error when code is 0
+var DefaultErrorMapper = NewErrorMapper()
+
+// NewErrorMapper create a empty ErrorMapper
+func NewErrorMapper() *ErrorMapper {
+	return &ErrorMapper{}
+}
+
+// Map respond status for errors which match sentinel with errors.Is
+func (m *ErrorMapper) Map(sentinel error, status int) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.mappings = append(m.mappings, errorMapping{sentinel: sentinel, status: status})
+}
+
+// MapType respond status for errors of the same type of example, matched with errors.As
+func (m *ErrorMapper) MapType(example error, status int) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.mappings = append(m.mappings, errorMapping{errorType: reflect.TypeOf(example), status: status})
+}
+
+// Status return the status of first mapping which match err, on order of registration
+func (m *ErrorMapper) Status(err error) (int, bool) {
+
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	for _, mapping := range m.mappings {
+
+		if mapping.sentinel != nil && errors.Is(err, mapping.sentinel) {
+			return mapping.status, true
+		}
+
+		if mapping.errorType != nil && errors.As(err, reflect.New(mapping.errorType).Interface()) {
+			return mapping.status, true
+		}
+	}
+
+	return 0, false
+}
+
+// Error send a error like Error, but using the mappings of m when code is 0
+func (m *ErrorMapper) Error(w http.ResponseWriter, err error, code int) (int, error) {
+	return newCall(context.Background()).writeError(w, err, m.resolve(err, code))
+}
+
+// resolve return the status to respond a error, a error which implements StatusCoder use its status,
+// otherwise code is used, and when code is 0 the mappings are used, falling back to 500.
+// A context.DeadlineExceeded is 504 instead of 500.
+func (m *ErrorMapper) resolve(err error, code int) int {
+
+	var coder StatusCoder
+
+	if errors.As(err, &coder) {
+		return coder.StatusCode()
+	}
+
+	// a 500 of a deadline is a timeout of server
+	if code == http.StatusInternalServerError && errors.Is(err, context.DeadlineExceeded) {
+		return http.StatusGatewayTimeout
+	}
+
+	if code != 0 {
+		return code
+	}
+
+	if status, ok := m.Status(err); ok {
+		return status
+	}
+
+	if errors.Is(err, context.DeadlineExceeded) {
+		return http.StatusGatewayTimeout
+	}
+
+	return http.StatusInternalServerError
+}
+
+// MapError respond status for errors which match sentinel on DefaultErrorMapper
+func MapError(sentinel error, status int) {
+	DefaultErrorMapper.Map(sentinel, status)
+}
+
+// MapErrorType respond status for errors of the same type of example on DefaultErrorMapper
+func MapErrorType(example error, status int) {
+	DefaultErrorMapper.MapType(example, status)
+}
@@ -0,0 +1,71 @@
+package rest_test
+
+import (
+	"errors"
+	"fmt"
+	"github.com/edermanoel94/rest-go"
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+var errProductNotFound = errors.New("product not found")
+
+type conflictError struct {
+	ID string
+}
+
+func (c *conflictError) Error() string {
+	return fmt.Sprintf("product %s already exists", c.ID)
+}
+
+func TestMapError(t *testing.T) {
+
+	rest.MapError(errProductNotFound, http.StatusNotFound)
+	rest.MapErrorType(&conflictError{}, http.StatusConflict)
+
+	testCases := []struct {
+		description string
+		err         error
+		code        int
+		statusCode  int
+	}{
+		{"should map a sentinel", errProductNotFound, 0, http.StatusNotFound},
+		{"should map a wrapped sentinel", fmt.Errorf("cannot update: %w", errProductNotFound), 0, http.StatusNotFound},
+		{"should map a wrapped type", fmt.Errorf("cannot create: %w", &conflictError{ID: "1"}), 0, http.StatusConflict},
+		{"should respond 500 when nothing match", errors.New("database is down"), 0, http.StatusInternalServerError},
+		{"should prefer the code given", errProductNotFound, http.StatusGone, http.StatusGone},
+	}
+
+	for _, tc := range testCases {
+
+		t.Run(tc.description, func(t *testing.T) {
+
+			recorder := httptest.NewRecorder()
+
+			rest.Error(recorder, tc.err, tc.code)
+
+			assert.Equal(t, tc.statusCode, recorder.Code)
+			assert.Equal(t, fmt.Sprintf(`{"message":"%s"}`, tc.err.Error()), recorder.Body.String())
+		})
+	}
+
+	t.Run("should use the mappings of instance", func(t *testing.T) {
+
+		mapper := rest.NewErrorMapper()
+
+		mapper.Map(errProductNotFound, http.StatusTeapot)
+
+		recorder := httptest.NewRecorder()
+
+		mapper.Error(recorder, errProductNotFound, 0)
+
+		assert.Equal(t, http.StatusTeapot, recorder.Code)
+
+		status, ok := mapper.Status(errors.New("other"))
+
+		assert.False(t, ok)
+		assert.Equal(t, 0, status)
+	})
+}
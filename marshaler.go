@@ -0,0 +1,92 @@
+package rest
+
+import (
+	"bytes"
+	"encoding/json"
+	"sync"
+)
+
+// Marshaler encode and decode json, used everywhere on package instead of encoding/json
+type Marshaler interface {
+	Marshal(v interface{}) ([]byte, error)
+	Unmarshal(data []byte, v interface{}) error
+}
+
+type standardMarshaler struct{}
+
+func (standardMarshaler) Marshal(v interface{}) ([]byte, error) {
+	return json.Marshal(v)
+}
+
+func (standardMarshaler) Unmarshal(data []byte, v interface{}) error {
+	return json.Unmarshal(data, v)
+}
+
+var (
+	marshalerMu sync.RWMutex
+	marshaler   Marshaler = standardMarshaler{}
+)
+
+// SetMarshaler replace encoding/json by other implementation, like jsoniter or go-json,
+// a nil marshaler restore encoding/json. The application/json encoder is replaced too.
+func SetMarshaler(m Marshaler) {
+
+	if m == nil {
+		m = standardMarshaler{}
+	}
+
+	marshalerMu.Lock()
+	marshaler = m
+	marshalerMu.Unlock()
+
+	RegisterEncoder(applicationJson, jsonEncoder{})
+}
+
+func currentMarshaler() Marshaler {
+	marshalerMu.RLock()
+	defer marshalerMu.RUnlock()
+	return marshaler
+}
+
+// customMarshaler report if SetMarshaler replaced encoding/json
+func customMarshaler() bool {
+	_, ok := currentMarshaler().(standardMarshaler)
+	return !ok
+}
+
+// jsonEncoder is the application/json encoder, encoding with the current marshaler
+type jsonEncoder struct{}
+
+func (jsonEncoder) Encode(v interface{}) ([]byte, error) {
+	return marshalJson(v)
+}
+
+// EncodeTo use a json.Encoder on buf when encoding/json is not replaced
+func (jsonEncoder) EncodeTo(buf *bytes.Buffer, v interface{}) error {
+
+	if customMarshaler() {
+		bytes, err := marshalJson(v)
+		if err != nil {
+			return err
+		}
+		buf.Write(bytes)
+		return nil
+	}
+
+	if err := json.NewEncoder(buf).Encode(v); err != nil {
+		return err
+	}
+
+	// json.Encoder end every value with a new line, json.Marshal don't
+	buf.Truncate(buf.Len() - 1)
+
+	return nil
+}
+
+func marshalJson(v interface{}) ([]byte, error) {
+	return currentMarshaler().Marshal(v)
+}
+
+func unmarshalJson(data []byte, v interface{}) error {
+	return currentMarshaler().Unmarshal(data, v)
+}
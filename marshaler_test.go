@@ -0,0 +1,101 @@
+package rest_test
+
+import (
+	"encoding/json"
+	"errors"
+	"github.com/edermanoel94/rest-go"
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+type countingMarshaler struct {
+	marshals   int
+	unmarshals int
+}
+
+func (c *countingMarshaler) Marshal(v interface{}) ([]byte, error) {
+	c.marshals++
+	return json.Marshal(v)
+}
+
+func (c *countingMarshaler) Unmarshal(data []byte, v interface{}) error {
+	c.unmarshals++
+	return json.Unmarshal(data, v)
+}
+
+func TestSetMarshaler(t *testing.T) {
+
+	t.Run("should marshal responses with marshaler", func(t *testing.T) {
+
+		marshaler := &countingMarshaler{}
+
+		rest.SetMarshaler(marshaler)
+		defer rest.SetMarshaler(nil)
+
+		recorder := httptest.NewRecorder()
+
+		rest.Marshalled(recorder, map[string]int{"id": 1}, http.StatusOK)
+
+		assert.Equal(t, `{"id":1}`, recorder.Body.String())
+		assert.Equal(t, 1, marshaler.marshals)
+	})
+
+	t.Run("should unmarshal requests with marshaler", func(t *testing.T) {
+
+		marshaler := &countingMarshaler{}
+
+		rest.SetMarshaler(marshaler)
+		defer rest.SetMarshaler(nil)
+
+		request := httptest.NewRequest(http.MethodPost, "/products", strings.NewReader(`{"name":"tv"}`))
+		request.Header.Set("Content-Type", "application/json")
+
+		var product struct {
+			Name string `json:"name"`
+		}
+
+		err := rest.Bind(request, &product)
+
+		assert.NoError(t, err)
+		assert.Equal(t, "tv", product.Name)
+		assert.Equal(t, 1, marshaler.unmarshals)
+	})
+
+	t.Run("should report malformed json decoded by marshaler", func(t *testing.T) {
+
+		rest.SetMarshaler(&countingMarshaler{})
+		defer rest.SetMarshaler(nil)
+
+		request := httptest.NewRequest(http.MethodPost, "/products", strings.NewReader(`{"name":`))
+		request.Header.Set("Content-Type", "application/json")
+
+		var product struct {
+			Name string `json:"name"`
+		}
+
+		err := rest.Bind(request, &product)
+
+		var bindErr *rest.BindError
+
+		assert.True(t, errors.As(err, &bindErr))
+		assert.Equal(t, http.StatusBadRequest, bindErr.Status)
+	})
+
+	t.Run("should restore encoding/json with nil", func(t *testing.T) {
+
+		marshaler := &countingMarshaler{}
+
+		rest.SetMarshaler(marshaler)
+		rest.SetMarshaler(nil)
+
+		recorder := httptest.NewRecorder()
+
+		rest.Marshalled(recorder, []int{1}, http.StatusOK)
+
+		assert.Equal(t, `[1]`, recorder.Body.String())
+		assert.Equal(t, 0, marshaler.marshals)
+	})
+}
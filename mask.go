@@ -0,0 +1,135 @@
+package rest
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"reflect"
+	"strings"
+	"sync"
+)
+
+// MaskFunc mask a value rendered on response
+type MaskFunc func(value string) string
+
+const redacted = "[redacted]"
+
+var (
+	masksMu sync.RWMutex
+	masks   = map[string]MaskFunc{
+		"email":  maskEmail,
+		"last4":  maskLast4,
+		"redact": maskRedact,
+	}
+)
+
+type scopesKey struct{}
+
+// RegisterMask add a mask to be used by tag mask:"name", email, last4 and redact are already registered
+func RegisterMask(name string, mask MaskFunc) {
+	masksMu.Lock()
+	defer masksMu.Unlock()
+	masks[name] = mask
+}
+
+// WithScopes attach the roles or scopes of principal to context, used by Masked to reveal fields
+func WithScopes(ctx context.Context, scopes ...string) context.Context {
+	return context.WithValue(ctx, scopesKey{}, scopes)
+}
+
+// ScopesFromContext return the roles or scopes attached to context
+func ScopesFromContext(ctx context.Context) []string {
+	if ctx == nil {
+		return nil
+	}
+	scopes, _ := ctx.Value(scopesKey{}).([]string)
+	return scopes
+}
+
+// Masked marshall and respond json like Marshalled, masking the fields with tag mask:"email", mask:"last4"
+// or mask:"redact". A field is revealed when the principal on context have a scope of reveal option,
+// like mask:"last4,reveal=admin|billing".
+func Masked(ctx context.Context, w http.ResponseWriter, v interface{}, code int) (int, error) {
+
+	s := &shaper{}
+
+	s.replace = append(s.replace, maskReplacer(ScopesFromContext(ctx)))
+
+	return marshalledShaped(ctx, w, s, v, code)
+}
+
+// maskReplacer mask the fields tagged with mask which scopes can't reveal
+func maskReplacer(scopes []string) func(field reflect.StructField, value reflect.Value) (interface{}, bool) {
+	return func(field reflect.StructField, value reflect.Value) (interface{}, bool) {
+
+		tag, ok := field.Tag.Lookup("mask")
+
+		if !ok {
+			return nil, false
+		}
+
+		name, opts := parseTag(tag)
+
+		for _, opt := range strings.Split(string(opts), ",") {
+			if key, reveal := splitDirective(opt); key == "reveal" && hasAnyScope(scopes, strings.Split(reveal, "|")) {
+				return nil, false
+			}
+		}
+
+		for value.Kind() == reflect.Ptr || value.Kind() == reflect.Interface {
+			if value.IsNil() {
+				return nil, true
+			}
+			value = value.Elem()
+		}
+
+		masksMu.RLock()
+		mask, ok := masks[name]
+		masksMu.RUnlock()
+
+		if !ok {
+			mask = maskRedact
+		}
+
+		return mask(fmt.Sprint(value.Interface())), true
+	}
+}
+
+func hasAnyScope(scopes, wanted []string) bool {
+	for _, scope := range scopes {
+		for _, w := range wanted {
+			if scope == w {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func maskEmail(value string) string {
+
+	at := strings.LastIndex(value, "@")
+
+	if at < 1 {
+		return maskRedact(value)
+	}
+
+	local := []rune(value[:at])
+
+	return string(local[:1]) + strings.Repeat("*", len(local)-1) + value[at:]
+}
+
+func maskLast4(value string) string {
+
+	runes := []rune(value)
+
+	if len(runes) <= 4 {
+		return strings.Repeat("*", len(runes))
+	}
+
+	return strings.Repeat("*", len(runes)-4) + string(runes[len(runes)-4:])
+}
+
+func maskRedact(string) string {
+	return redacted
+}
@@ -0,0 +1,83 @@
+package rest_test
+
+import (
+	"context"
+	"github.com/edermanoel94/rest-go"
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+type customer struct {
+	Name     string  `json:"name"`
+	Email    string  `json:"email" mask:"email"`
+	Card     string  `json:"card" mask:"last4,reveal=billing|admin"`
+	Password string  `json:"password" mask:"redact"`
+	Document *string `json:"document" mask:"upper"`
+	Phone    *string `json:"phone" mask:"last4"`
+}
+
+func TestMasked(t *testing.T) {
+
+	rest.RegisterMask("upper", strings.ToUpper)
+
+	document := "abc"
+
+	payload := customer{
+		Name:     "eder",
+		Email:    "eder@example.com",
+		Card:     "4111111111111111",
+		Password: "secret",
+		Document: &document,
+	}
+
+	testCases := []struct {
+		description string
+		scopes      []string
+		expected    string
+	}{
+		{"should mask fields without principal", nil,
+			`{"name":"eder","email":"e***@example.com","card":"************1111","password":"[redacted]","document":"ABC","phone":null}`},
+		{"should reveal fields to principal with scope", []string{"billing"},
+			`{"name":"eder","email":"e***@example.com","card":"4111111111111111","password":"[redacted]","document":"ABC","phone":null}`},
+	}
+
+	for _, tc := range testCases {
+
+		t.Run(tc.description, func(t *testing.T) {
+
+			ctx := rest.WithScopes(context.Background(), tc.scopes...)
+
+			recorder := httptest.NewRecorder()
+
+			rest.Masked(ctx, recorder, []customer{payload}, http.StatusOK)
+
+			assert.Equal(t, http.StatusOK, recorder.Code)
+			assert.Equal(t, "["+tc.expected+"]", recorder.Body.String())
+		})
+	}
+}
+
+func TestMaskedMap(t *testing.T) {
+
+	t.Run("should mask values of map with int keys", func(t *testing.T) {
+
+		recorder := httptest.NewRecorder()
+
+		rest.Masked(context.Background(), recorder, map[int]customer{1: {Email: "john@x.com", Card: "1234567812345678"}}, http.StatusOK)
+
+		assert.Equal(t, `{"1":{"name":"","email":"j***@x.com","card":"************5678","password":"[redacted]","document":null,"phone":null}}`,
+			recorder.Body.String())
+	})
+
+	t.Run("should mask email by runes", func(t *testing.T) {
+
+		recorder := httptest.NewRecorder()
+
+		rest.Masked(context.Background(), recorder, customer{Email: "éjohn@x.com"}, http.StatusOK)
+
+		assert.Contains(t, recorder.Body.String(), `"email":"é****@x.com"`)
+	})
+}
@@ -1,11 +1,136 @@
 package rest
 
+import (
+	"mime"
+	"sort"
+	"strconv"
+	"strings"
+)
+
 // Headers keys
 const (
 	contentType = "Content-Type"
+	accept      = "Accept"
 )
 
 // Headers values
 const (
 	applicationJson = "application/json"
+	applicationXml  = "application/xml"
 )
+
+// isJsonMediaType report if a Content-Type is json, like application/json or application/hal+json
+func isJsonMediaType(value string) bool {
+
+	mediaType, _, err := mime.ParseMediaType(value)
+
+	if err != nil {
+		return false
+	}
+
+	return mediaType == applicationJson || strings.HasSuffix(mediaType, "+json")
+}
+
+// mediaRange is a media type accepted by client, like text/* or application/json;q=0.9
+type mediaRange struct {
+	mediaType string
+	params    map[string]string
+	quality   float64
+}
+
+// matches report if mediaRange accept mediaType
+func (m mediaRange) matches(mediaType string) bool {
+
+	if m.mediaType == "*/*" || m.mediaType == mediaType {
+		return true
+	}
+
+	if strings.HasSuffix(m.mediaType, "/*") {
+		return strings.HasPrefix(mediaType, strings.TrimSuffix(m.mediaType, "*"))
+	}
+
+	return false
+}
+
+func (m mediaRange) specificity() int {
+	switch {
+	case m.mediaType == "*/*":
+		return 0
+	case strings.HasSuffix(m.mediaType, "/*"):
+		return 1
+	}
+	return 2 + len(m.params)
+}
+
+// parseAccept return the media ranges of Accept header, ordered by quality and specificity
+func parseAccept(header string) []mediaRange {
+
+	ranges := make([]mediaRange, 0)
+
+	for _, part := range strings.Split(header, ",") {
+
+		if strings.TrimSpace(part) == "" {
+			continue
+		}
+
+		mediaType, params, err := mime.ParseMediaType(part)
+
+		if err != nil {
+			continue
+		}
+
+		quality := 1.0
+
+		if q, ok := params["q"]; ok {
+			if value, err := strconv.ParseFloat(q, 64); err == nil {
+				quality = value
+			}
+			delete(params, "q")
+		}
+
+		ranges = append(ranges, mediaRange{mediaType: mediaType, params: params, quality: quality})
+	}
+
+	sort.SliceStable(ranges, func(i, j int) bool {
+		if ranges[i].quality != ranges[j].quality {
+			return ranges[i].quality > ranges[j].quality
+		}
+		return ranges[i].specificity() > ranges[j].specificity()
+	})
+
+	return ranges
+}
+
+// negotiateMediaType choose the best of offers for Accept header, each offer get the quality of the
+// most specific range which match it. The first offer is returned when header is empty, and a empty
+// string when nothing is acceptable.
+func negotiateMediaType(header string, offers ...string) string {
+
+	if strings.TrimSpace(header) == "" {
+		if len(offers) > 0 {
+			return offers[0]
+		}
+		return ""
+	}
+
+	ranges := parseAccept(header)
+
+	best, bestQuality := "", 0.0
+
+	for _, offer := range offers {
+
+		quality, specificity := 0.0, -1
+
+		for _, accepted := range ranges {
+			if accepted.matches(offer) && accepted.specificity() > specificity {
+				quality, specificity = accepted.quality, accepted.specificity()
+			}
+		}
+
+		if quality > bestQuality {
+			best, bestQuality = offer, quality
+		}
+	}
+
+	return best
+}
@@ -0,0 +1,18 @@
+module github.com/edermanoel94/rest-go/msgpack
+
+go 1.20
+
+replace github.com/edermanoel94/rest-go => ../
+
+require (
+	github.com/edermanoel94/rest-go v0.0.0-00010101000000-000000000000
+	github.com/stretchr/testify v1.6.1
+	github.com/vmihailenco/msgpack/v5 v5.4.1
+)
+
+require (
+	github.com/davecgh/go-spew v1.1.0 // indirect
+	github.com/pmezard/go-difflib v1.0.0 // indirect
+	github.com/vmihailenco/tagparser/v2 v2.0.0 // indirect
+	gopkg.in/yaml.v3 v3.0.0-20200313102051-9f266ea9e77c // indirect
+)
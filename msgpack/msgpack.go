@@ -0,0 +1,40 @@
+// Package msgpack register a application/msgpack encoder on rest, importing it is enough
+// to negotiate msgpack, and Msgpack send it explicitly. Struct fields use the json tags.
+package msgpack
+
+import (
+	"bytes"
+	"github.com/edermanoel94/rest-go"
+	"github.com/vmihailenco/msgpack/v5"
+	"net/http"
+)
+
+// MediaType is the content type of msgpack responses
+const MediaType = "application/msgpack"
+
+func init() {
+	rest.RegisterEncoder(MediaType, Encoder{})
+}
+
+// Encoder marshal values to msgpack, using the json tags of struct fields
+type Encoder struct{}
+
+// Encode marshal v to msgpack
+func (Encoder) Encode(v interface{}) ([]byte, error) {
+
+	buf := &bytes.Buffer{}
+
+	enc := msgpack.NewEncoder(buf)
+	enc.SetCustomStructTag("json")
+
+	if err := enc.Encode(v); err != nil {
+		return nil, err
+	}
+
+	return buf.Bytes(), nil
+}
+
+// Msgpack marshall v and respond application/msgpack, errors are sent on msgpack too
+func Msgpack(w http.ResponseWriter, v interface{}, code int, opts ...rest.Option) (int, error) {
+	return rest.Encoded(w, MediaType, v, code, opts...)
+}
@@ -0,0 +1,63 @@
+package msgpack_test
+
+import (
+	"github.com/edermanoel94/rest-go"
+	"github.com/edermanoel94/rest-go/msgpack"
+	"github.com/stretchr/testify/assert"
+	decoder "github.com/vmihailenco/msgpack/v5"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+type product struct {
+	ID   int    `json:"id"`
+	Name string `json:"name"`
+}
+
+func TestMsgpack(t *testing.T) {
+
+	t.Run("should marshal to msgpack with json tags", func(t *testing.T) {
+
+		recorder := httptest.NewRecorder()
+
+		rest.Encoded(recorder, msgpack.MediaType, product{ID: 1, Name: "tv"}, http.StatusOK)
+
+		var decoded map[string]interface{}
+
+		err := decoder.Unmarshal(recorder.Body.Bytes(), &decoded)
+
+		assert.NoError(t, err)
+		assert.Equal(t, "application/msgpack", recorder.Header().Get("Content-Type"))
+		assert.Equal(t, map[string]interface{}{"id": int8(1), "name": "tv"}, decoded)
+	})
+
+	t.Run("should negotiate msgpack", func(t *testing.T) {
+
+		request := httptest.NewRequest(http.MethodGet, "/products", nil)
+		request.Header.Set("Accept", "application/msgpack")
+
+		recorder := httptest.NewRecorder()
+
+		rest.Negotiate(recorder, request, []product{{ID: 1}}, http.StatusOK)
+
+		assert.Equal(t, "application/msgpack", recorder.Header().Get("Content-Type"))
+	})
+
+	t.Run("should send error on msgpack", func(t *testing.T) {
+
+		recorder := httptest.NewRecorder()
+
+		msgpack.Msgpack(recorder, make(chan int), http.StatusOK)
+
+		var decoded map[string]interface{}
+
+		err := decoder.Unmarshal(recorder.Body.Bytes(), &decoded)
+
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusInternalServerError, recorder.Code)
+		assert.Equal(t, "application/msgpack", recorder.Header().Get("Content-Type"))
+		assert.Contains(t, decoded["message"], "chan int")
+	})
+
+}
@@ -0,0 +1,40 @@
+package rest
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+var (
+	ErrNotAcceptable = errors.New("not acceptable")
+)
+
+// Negotiate marshall v with the registered encoder which best match the Accept header, json is
+// used when the client accept anything, and 406 is sent when no encoder is acceptable
+func Negotiate(w http.ResponseWriter, r *http.Request, v interface{}, code int, opts ...Option) (int, error) {
+
+	c := newCall(r.Context(), opts...)
+
+	w.Header().Add("Vary", accept)
+
+	offers := mediaTypes()
+
+	mediaType := negotiateMediaType(r.Header.Get(accept), offers...)
+
+	if mediaType == "" {
+		err := fmt.Errorf("%w, supported media types: %s", ErrNotAcceptable, strings.Join(offers, ", "))
+		return c.fail(w, err, http.StatusNotAcceptable)
+	}
+
+	c.errorType = mediaType
+
+	bytes, err := encode(mediaType, v)
+
+	if err != nil {
+		return c.fail(w, err, http.StatusInternalServerError)
+	}
+
+	return c.write(w, mediaType, bytes, code)
+}
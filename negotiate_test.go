@@ -0,0 +1,67 @@
+package rest_test
+
+import (
+	"github.com/edermanoel94/rest-go"
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+type book struct {
+	Title string `json:"title" xml:"title"`
+}
+
+func TestNegotiate(t *testing.T) {
+
+	testCases := []struct {
+		description string
+		accept      string
+		statusCode  int
+		contentType string
+		body        string
+	}{
+		{"should fallback to json without accept", "", http.StatusOK, "application/json", `{"title":"go"}`},
+		{"should use json when accept anything", "*/*", http.StatusOK, "application/json", `{"title":"go"}`},
+		{"should use xml when requested", "application/xml", http.StatusOK, "application/xml", `<book><title>go</title></book>`},
+		{"should respect quality", "application/json;q=0.5, application/xml", http.StatusOK, "application/xml", `<book><title>go</title></book>`},
+		{"should respect a rejected media type", "application/json;q=0, */*", http.StatusOK, "application/xml", `<book><title>go</title></book>`},
+		{"should respond 406 when nothing match", "text/html", http.StatusNotAcceptable, "application/json",
+			`{"message":"not acceptable, supported media types: application/json, application/xml"}`},
+	}
+
+	for _, tc := range testCases {
+
+		t.Run(tc.description, func(t *testing.T) {
+
+			request := httptest.NewRequest(http.MethodGet, "/", nil)
+			request.Header.Set("Accept", tc.accept)
+
+			recorder := httptest.NewRecorder()
+
+			rest.Negotiate(recorder, request, &book{Title: "go"}, http.StatusOK)
+
+			assert.Equal(t, tc.statusCode, recorder.Code)
+			assert.Equal(t, tc.contentType, recorder.Header().Get("Content-Type"))
+			assert.Equal(t, "Accept", recorder.Header().Get("Vary"))
+			assert.Equal(t, tc.body, recorder.Body.String())
+		})
+	}
+}
+
+func TestNegotiateErrorFormat(t *testing.T) {
+
+	t.Run("should send error on media type negotiated", func(t *testing.T) {
+
+		request := httptest.NewRequest(http.MethodGet, "/products", nil)
+		request.Header.Set("Accept", "application/xml")
+
+		recorder := httptest.NewRecorder()
+
+		rest.Negotiate(recorder, request, map[string]string{"name": "tv"}, http.StatusOK)
+
+		assert.Equal(t, http.StatusInternalServerError, recorder.Code)
+		assert.Equal(t, "application/xml", recorder.Header().Get("Content-Type"))
+		assert.Contains(t, recorder.Body.String(), "<error><message>")
+	})
+}
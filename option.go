@@ -0,0 +1,171 @@
+package rest
+
+import (
+	"fmt"
+	"mime"
+	"net/http"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// Option customize a response sent by Response, Marshalled and Error
+type Option func(*options)
+
+type options struct {
+	header       http.Header
+	contentType  string
+	cacheControl []string
+	expires      time.Time
+	// indent is nil when the global indent must be used
+	indent   *string
+	location string
+	// emptyCollections send nil slices and maps as [] and {}
+	emptyCollections bool
+	// maxBodySize and sizePolicy are nil when the global ones must be used
+	maxBodySize *int
+	sizePolicy  *SizePolicy
+}
+
+func newOptions(opts []Option) options {
+
+	o := options{}
+
+	for _, opt := range opts {
+		opt(&o)
+	}
+
+	return o
+}
+
+// apply set the headers of options, overriding the default headers, cache headers are not sent on server errors
+func (o *options) apply(header http.Header, code int) {
+
+	for key, values := range o.header {
+		header[key] = values
+	}
+
+	if o.location != "" && code < http.StatusBadRequest {
+		header.Set("Location", o.location)
+	}
+
+	if code >= http.StatusInternalServerError {
+		return
+	}
+
+	if len(o.cacheControl) > 0 {
+		header.Set("Cache-Control", strings.Join(o.cacheControl, ", "))
+	}
+
+	if !o.expires.IsZero() {
+		header.Set("Expires", o.expires.UTC().Format(http.TimeFormat))
+	}
+}
+
+// Location send the url of resource on Location header, which is not sent on errors
+func Location(url string) Option {
+	return func(o *options) {
+		o.location = url
+	}
+}
+
+// EmptyArrays send nil slices as [] and nil maps as {} instead of null, on every depth of value marshalled.
+// Values which implements json.Marshaler are sent as they marshal themselves.
+func EmptyArrays() Option {
+	return func(o *options) {
+		o.emptyCollections = true
+	}
+}
+
+// shaper return a shaper which follow the options, like EmptyArrays
+func (o *options) shaper() *shaper {
+	return &shaper{emptyCollections: o.emptyCollections}
+}
+
+// Attachment make the response a download named filename, like a export on CSV
+func Attachment(filename string) Option {
+	return WithHeader("Content-Disposition", attachment(filename))
+}
+
+func attachment(filename string) string {
+	return mime.FormatMediaType("attachment", map[string]string{"filename": filepath.Base(filename)})
+}
+
+// mediaType return the content type of options, or the media type given when is not set
+func (o *options) mediaType(mediaType string) string {
+	if o.contentType != "" {
+		return o.contentType
+	}
+	return mediaType
+}
+
+// WithHeader set a header on response, can be given many times for same key to send many values
+func WithHeader(key, value string) Option {
+	return func(o *options) {
+		if o.header == nil {
+			o.header = make(http.Header)
+		}
+		o.header.Add(key, value)
+	}
+}
+
+// WithContentType change the Content-Type of a success response, like application/hal+json,
+// errors are always sent as application/json
+func WithContentType(mediaType string) Option {
+	return func(o *options) {
+		o.contentType = mediaType
+	}
+}
+
+func cacheDirective(directive string) Option {
+	return func(o *options) {
+		o.cacheControl = append(o.cacheControl, directive)
+	}
+}
+
+// MaxAge add max-age directive on Cache-Control
+func MaxAge(d time.Duration) Option {
+	return cacheDirective(fmt.Sprintf("max-age=%d", int(d.Seconds())))
+}
+
+// SharedMaxAge add s-maxage directive on Cache-Control, used by shared caches like CDNs
+func SharedMaxAge(d time.Duration) Option {
+	return cacheDirective(fmt.Sprintf("s-maxage=%d", int(d.Seconds())))
+}
+
+// Public add public directive on Cache-Control
+func Public() Option {
+	return cacheDirective("public")
+}
+
+// Private add private directive on Cache-Control, just the client can cache the response
+func Private() Option {
+	return cacheDirective("private")
+}
+
+// NoCache add no-cache directive on Cache-Control, the cache must revalidate before use
+func NoCache() Option {
+	return cacheDirective("no-cache")
+}
+
+// NoStore add no-store directive on Cache-Control, the response must not be cached
+func NoStore() Option {
+	return cacheDirective("no-store")
+}
+
+// MustRevalidate add must-revalidate directive on Cache-Control
+func MustRevalidate() Option {
+	return cacheDirective("must-revalidate")
+}
+
+// Immutable add immutable directive on Cache-Control
+func Immutable() Option {
+	return cacheDirective("immutable")
+}
+
+// Expires set the Expires header, used by old caches which don't support Cache-Control
+func Expires(t time.Time) Option {
+	return func(o *options) {
+		o.expires = t
+	}
+}
@@ -0,0 +1,202 @@
+package rest_test
+
+import (
+	"errors"
+	"github.com/edermanoel94/rest-go"
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestCacheOptions(t *testing.T) {
+
+	t.Run("should send cache directives on order", func(t *testing.T) {
+
+		recorder := httptest.NewRecorder()
+
+		rest.Response(recorder, []byte(`{"name":"tv"}`), http.StatusOK,
+			rest.MaxAge(5*time.Minute), rest.Private(), rest.MustRevalidate())
+
+		assert.Equal(t, "max-age=300, private, must-revalidate", recorder.Header().Get("Cache-Control"))
+	})
+
+	t.Run("should send shared cache directives and expires", func(t *testing.T) {
+
+		expires := time.Date(2030, 1, 2, 3, 4, 5, 0, time.UTC)
+
+		recorder := httptest.NewRecorder()
+
+		rest.Marshalled(recorder, []int{1}, http.StatusOK,
+			rest.Public(), rest.SharedMaxAge(time.Hour), rest.Immutable(), rest.Expires(expires))
+
+		assert.Equal(t, "public, s-maxage=3600, immutable", recorder.Header().Get("Cache-Control"))
+		assert.Equal(t, "Wed, 02 Jan 2030 03:04:05 GMT", recorder.Header().Get("Expires"))
+	})
+
+	t.Run("should send no store on errors", func(t *testing.T) {
+
+		recorder := httptest.NewRecorder()
+
+		rest.Error(recorder, errors.New("not found"), http.StatusNotFound, rest.NoStore(), rest.NoCache())
+
+		assert.Equal(t, "no-store, no-cache", recorder.Header().Get("Cache-Control"))
+	})
+
+	t.Run("should not cache server errors", func(t *testing.T) {
+
+		recorder := httptest.NewRecorder()
+
+		rest.Response(recorder, []byte(`not json`), http.StatusOK, rest.MaxAge(time.Hour))
+
+		assert.Equal(t, http.StatusInternalServerError, recorder.Code)
+		assert.Empty(t, recorder.Header().Get("Cache-Control"))
+	})
+
+	t.Run("should send cache directives on not modified", func(t *testing.T) {
+
+		payload := []byte(`{"name":"tv"}`)
+
+		request := httptest.NewRequest(http.MethodGet, "/", nil)
+		request.Header.Set("If-None-Match", rest.ETag(payload))
+
+		recorder := httptest.NewRecorder()
+
+		rest.ContentConditional(recorder, request, payload, http.StatusOK, rest.MaxAge(time.Minute))
+
+		assert.Equal(t, http.StatusNotModified, recorder.Code)
+		assert.Equal(t, "max-age=60", recorder.Header().Get("Cache-Control"))
+	})
+}
+
+func TestHeaderOptions(t *testing.T) {
+
+	t.Run("should send headers and content type", func(t *testing.T) {
+
+		recorder := httptest.NewRecorder()
+
+		rest.Marshalled(recorder, map[string]int{"id": 1}, http.StatusOK,
+			rest.WithContentType("application/hal+json"),
+			rest.WithHeader("X-Total", "10"),
+			rest.WithHeader("Link", "</a>; rel=\"a\""),
+			rest.WithHeader("Link", "</b>; rel=\"b\""))
+
+		assert.Equal(t, "application/hal+json", recorder.Header().Get("Content-Type"))
+		assert.Equal(t, "10", recorder.Header().Get("X-Total"))
+		assert.Equal(t, []string{"</a>; rel=\"a\"", "</b>; rel=\"b\""}, recorder.Header()["Link"])
+	})
+
+	t.Run("should override default headers", func(t *testing.T) {
+
+		rest.SetDefaultHeaders(map[string]string{"X-Frame-Options": "DENY"})
+
+		defer rest.SetDefaultHeaders(nil)
+
+		recorder := httptest.NewRecorder()
+
+		rest.Response(recorder, []byte(`{}`), http.StatusOK, rest.WithHeader("x-frame-options", "SAMEORIGIN"))
+
+		assert.Equal(t, "SAMEORIGIN", recorder.Header().Get("X-Frame-Options"))
+	})
+
+	t.Run("should send errors as json", func(t *testing.T) {
+
+		recorder := httptest.NewRecorder()
+
+		rest.Error(recorder, errors.New("not found"), http.StatusNotFound,
+			rest.WithContentType("application/hal+json"), rest.WithHeader("X-Reason", "missing"))
+
+		assert.Equal(t, "application/json", recorder.Header().Get("Content-Type"))
+		assert.Equal(t, "missing", recorder.Header().Get("X-Reason"))
+	})
+}
+
+type emptyArraysProduct struct {
+	Name     string            `json:"name"`
+	Tags     []string          `json:"tags"`
+	Metadata map[string]string `json:"metadata"`
+	Parts    []emptyArraysPart `json:"parts"`
+	Image    []byte            `json:"image"`
+	Note     *string           `json:"note"`
+}
+
+type emptyArraysPart struct {
+	Codes []int `json:"codes"`
+}
+
+type emptyArraysNode struct {
+	Name string           `json:"name"`
+	Next *emptyArraysNode `json:"next"`
+}
+
+func TestEmptyArrays(t *testing.T) {
+
+	testCases := []struct {
+		description string
+		value       interface{}
+		body        string
+	}{
+		{"should send nil slices and maps as empty", &emptyArraysProduct{Name: "tv", Parts: []emptyArraysPart{{}}},
+			`{"name":"tv","tags":[],"metadata":{},"parts":[{"codes":[]}],"image":null,"note":null}`},
+		{"should send nil slice given as empty", []int(nil), `[]`},
+		{"should send nil map given as empty", map[string]int(nil), `{}`},
+	}
+
+	for _, tc := range testCases {
+
+		t.Run(tc.description, func(t *testing.T) {
+
+			recorder := httptest.NewRecorder()
+
+			rest.Marshalled(recorder, tc.value, http.StatusOK, rest.EmptyArrays())
+
+			assert.Equal(t, http.StatusOK, recorder.Code)
+			assert.Equal(t, tc.body, recorder.Body.String())
+		})
+	}
+
+	t.Run("should send null without option", func(t *testing.T) {
+
+		recorder := httptest.NewRecorder()
+
+		rest.Marshalled(recorder, &emptyArraysProduct{Name: "tv"}, http.StatusOK)
+
+		assert.Equal(t, `{"name":"tv","tags":null,"metadata":null,"parts":null,"image":null,"note":null}`, recorder.Body.String())
+	})
+
+	t.Run("should send empty arrays on filtered fields", func(t *testing.T) {
+
+		recorder := httptest.NewRecorder()
+
+		request := httptest.NewRequest(http.MethodGet, "/?fields=tags", nil)
+
+		rest.Filtered(recorder, request, &emptyArraysProduct{Name: "tv"}, http.StatusOK, rest.EmptyArrays())
+
+		assert.Equal(t, `{"tags":[]}`, recorder.Body.String())
+	})
+
+	t.Run("should respond internal server error on value which reference itself", func(t *testing.T) {
+
+		node := &emptyArraysNode{Name: "root"}
+		node.Next = node
+
+		recorder := httptest.NewRecorder()
+
+		rest.Marshalled(recorder, node, http.StatusOK, rest.EmptyArrays())
+
+		assert.Equal(t, http.StatusInternalServerError, recorder.Code)
+		assert.Contains(t, recorder.Body.String(), "encountered a cycle")
+	})
+
+	t.Run("should send the same pointer twice when it is not a cycle", func(t *testing.T) {
+
+		part := &emptyArraysPart{}
+
+		recorder := httptest.NewRecorder()
+
+		rest.Marshalled(recorder, []*emptyArraysPart{part, part}, http.StatusOK, rest.EmptyArrays())
+
+		assert.Equal(t, `[{"codes":[]},{"codes":[]}]`, recorder.Body.String())
+	})
+}
@@ -0,0 +1,181 @@
+// Package otel record OpenTelemetry spans and metrics for the responses sent by rest, with the
+// status, the encode duration, the bytes sent and the media type. The span on context of a write,
+// like on ErrorCtx and MarshalledCtx, get a child span rest.write and the errors sent by rest.
+//
+// Just the helpers which receive a context or the request, like ErrorCtx, MarshalledCtx, ResponseCtx,
+// Handler and File, carry the request context. The others, like Error and Marshalled, use
+// context.Background: their writes are counted on metrics, but Config.Attributes receive a context
+// without the values of request and no span is annotated. Use the Ctx helpers on traced handlers.
+package otel
+
+import (
+	"context"
+	"github.com/edermanoel94/rest-go"
+	global "go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/metric"
+	"go.opentelemetry.io/otel/trace"
+	"mime"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// ScopeName is the name of tracer and meter
+const ScopeName = "github.com/edermanoel94/rest-go/otel"
+
+// Attributes keys of spans and metrics
+const (
+	StatusKey         = attribute.Key("http.response.status_code")
+	BodySizeKey       = attribute.Key("http.response.body.size")
+	FormatKey         = attribute.Key("rest.format")
+	EncodeDurationKey = attribute.Key("rest.encode_duration")
+)
+
+// Config configure the instrumentation
+type Config struct {
+	// TracerProvider create the spans, the global provider is used when nil
+	TracerProvider trace.TracerProvider
+	// MeterProvider create the metrics, the global provider is used when nil
+	MeterProvider metric.MeterProvider
+	// Attributes return attributes added to spans and metrics of a write, like http.route
+	Attributes func(ctx context.Context) []attribute.KeyValue
+}
+
+var (
+	currentMu sync.RWMutex
+	// current is the instrumentation of last call of Instrument, the hooks of previous calls do nothing
+	current *instrumentation
+)
+
+type instrumentation struct {
+	config         Config
+	tracer         trace.Tracer
+	encodeDuration metric.Float64Histogram
+	bodySize       metric.Int64Histogram
+	responses      metric.Int64Counter
+}
+
+// Instrument record every response sent by rest, see rest.OnWrite. The metrics are the histograms
+// rest.response.encode.duration and rest.response.body.size, and the counter rest.responses.
+// Calling Instrument again replace the config, the responses are not recorded twice.
+func Instrument(config Config) error {
+
+	if config.TracerProvider == nil {
+		config.TracerProvider = global.GetTracerProvider()
+	}
+
+	if config.MeterProvider == nil {
+		config.MeterProvider = global.GetMeterProvider()
+	}
+
+	meter := config.MeterProvider.Meter(ScopeName)
+
+	i := &instrumentation{config: config, tracer: config.TracerProvider.Tracer(ScopeName)}
+
+	var err error
+
+	i.encodeDuration, err = meter.Float64Histogram("rest.response.encode.duration",
+		metric.WithDescription("Time spent encoding the body of responses"), metric.WithUnit("s"))
+
+	if err != nil {
+		return err
+	}
+
+	i.bodySize, err = meter.Int64Histogram("rest.response.body.size",
+		metric.WithDescription("Size of the body of responses"), metric.WithUnit("By"))
+
+	if err != nil {
+		return err
+	}
+
+	i.responses, err = meter.Int64Counter("rest.responses",
+		metric.WithDescription("Number of responses sent"), metric.WithUnit("{response}"))
+
+	if err != nil {
+		return err
+	}
+
+	currentMu.Lock()
+	current = i
+	currentMu.Unlock()
+
+	rest.OnWrite(func(event rest.Event) {
+
+		currentMu.RLock()
+		active := current == i
+		currentMu.RUnlock()
+
+		if active {
+			i.record(event)
+		}
+	})
+
+	return nil
+}
+
+// record add the span and metrics of a response
+func (i *instrumentation) record(event rest.Event) {
+
+	ctx := event.Context
+
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	attrs := []attribute.KeyValue{StatusKey.Int(event.Status), FormatKey.String(format(event.ContentType))}
+
+	if i.config.Attributes != nil {
+		attrs = append(attrs, i.config.Attributes(ctx)...)
+	}
+
+	set := metric.WithAttributes(attrs...)
+
+	i.encodeDuration.Record(ctx, event.EncodeDuration.Seconds(), set)
+	i.bodySize.Record(ctx, int64(event.Bytes), set)
+	i.responses.Add(ctx, 1, set)
+
+	parent := trace.SpanFromContext(ctx)
+
+	// there is no request traced
+	if !parent.SpanContext().IsValid() {
+		return
+	}
+
+	end := time.Now()
+
+	_, span := i.tracer.Start(ctx, "rest.write",
+		trace.WithTimestamp(end.Add(-event.Duration)),
+		trace.WithSpanKind(trace.SpanKindInternal),
+		trace.WithAttributes(attrs...),
+		trace.WithAttributes(BodySizeKey.Int(event.Bytes), EncodeDurationKey.Float64(event.EncodeDuration.Seconds())))
+
+	if event.Err != nil {
+
+		span.RecordError(event.Err)
+		parent.RecordError(event.Err)
+
+		if event.Status >= http.StatusInternalServerError {
+			span.SetStatus(codes.Error, event.Err.Error())
+			parent.SetStatus(codes.Error, event.Err.Error())
+		}
+	}
+
+	if event.WriteErr != nil {
+		span.RecordError(event.WriteErr)
+		span.SetStatus(codes.Error, event.WriteErr.Error())
+	}
+
+	span.End(trace.WithTimestamp(end))
+}
+
+// format return the media type without parameters, like application/json
+func format(contentType string) string {
+
+	if mediaType, _, err := mime.ParseMediaType(contentType); err == nil {
+		return mediaType
+	}
+
+	return contentType
+}
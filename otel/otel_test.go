@@ -0,0 +1,158 @@
+package otel_test
+
+import (
+	"context"
+	"errors"
+	"github.com/edermanoel94/rest-go"
+	"github.com/edermanoel94/rest-go/otel"
+	"github.com/stretchr/testify/assert"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func setup(t *testing.T) (*tracetest.SpanRecorder, *sdktrace.TracerProvider, *sdkmetric.ManualReader) {
+
+	spans := tracetest.NewSpanRecorder()
+	tracerProvider := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(spans))
+
+	reader := sdkmetric.NewManualReader()
+	meterProvider := sdkmetric.NewMeterProvider(sdkmetric.WithReader(reader))
+
+	err := otel.Instrument(otel.Config{
+		TracerProvider: tracerProvider,
+		MeterProvider:  meterProvider,
+		Attributes: func(ctx context.Context) []attribute.KeyValue {
+			return []attribute.KeyValue{attribute.String("http.route", "/products")}
+		},
+	})
+
+	assert.NoError(t, err)
+
+	t.Cleanup(rest.ResetWriteHooks)
+
+	return spans, tracerProvider, reader
+}
+
+func TestInstrument(t *testing.T) {
+
+	t.Run("should record a span of write", func(t *testing.T) {
+
+		spans, tracerProvider, _ := setup(t)
+
+		ctx, parent := tracerProvider.Tracer("test").Start(context.Background(), "GET /products")
+
+		rest.MarshalledCtx(ctx, httptest.NewRecorder(), []int{1, 2}, http.StatusOK)
+
+		parent.End()
+
+		ended := spans.Ended()
+
+		assert.Len(t, ended, 2)
+		assert.Equal(t, "rest.write", ended[0].Name())
+		assert.Equal(t, parent.SpanContext().SpanID(), ended[0].Parent().SpanID())
+		assert.Contains(t, ended[0].Attributes(), attribute.Int("http.response.status_code", http.StatusOK))
+		assert.Contains(t, ended[0].Attributes(), attribute.String("rest.format", "application/json"))
+		assert.Contains(t, ended[0].Attributes(), attribute.Int("http.response.body.size", 5))
+		assert.Contains(t, ended[0].Attributes(), attribute.String("http.route", "/products"))
+		assert.Equal(t, codes.Unset, ended[0].Status().Code)
+	})
+
+	t.Run("should annotate the span with errors", func(t *testing.T) {
+
+		spans, tracerProvider, _ := setup(t)
+
+		ctx, parent := tracerProvider.Tracer("test").Start(context.Background(), "GET /products")
+
+		rest.ErrorCtx(ctx, httptest.NewRecorder(), errors.New("database is down"), http.StatusInternalServerError)
+
+		parent.End()
+
+		ended := spans.Ended()
+
+		assert.Len(t, ended, 2)
+
+		for _, span := range ended {
+			assert.Equal(t, codes.Error, span.Status().Code)
+			assert.Equal(t, "database is down", span.Status().Description)
+			assert.Len(t, span.Events(), 1)
+			assert.Equal(t, "exception", span.Events()[0].Name)
+		}
+	})
+
+	t.Run("should not record a span without request traced", func(t *testing.T) {
+
+		spans, _, _ := setup(t)
+
+		rest.Marshalled(httptest.NewRecorder(), []int{1}, http.StatusOK)
+
+		assert.Empty(t, spans.Ended())
+	})
+
+	t.Run("should record metrics of write", func(t *testing.T) {
+
+		_, _, reader := setup(t)
+
+		rest.Marshalled(httptest.NewRecorder(), []int{1}, http.StatusCreated)
+		rest.Error(httptest.NewRecorder(), errors.New("not found"), http.StatusNotFound)
+
+		data := metricdata.ResourceMetrics{}
+
+		assert.NoError(t, reader.Collect(context.Background(), &data))
+		assert.Len(t, data.ScopeMetrics, 1)
+
+		metrics := make(map[string]metricdata.Metrics)
+
+		for _, m := range data.ScopeMetrics[0].Metrics {
+			metrics[m.Name] = m
+		}
+
+		assert.Contains(t, metrics, "rest.response.encode.duration")
+		assert.Contains(t, metrics, "rest.response.body.size")
+
+		counter := metrics["rest.responses"].Data.(metricdata.Sum[int64])
+
+		assert.Len(t, counter.DataPoints, 2)
+
+		for _, point := range counter.DataPoints {
+			status, _ := point.Attributes.Value("http.response.status_code")
+			assert.Contains(t, []int64{http.StatusCreated, http.StatusNotFound}, status.AsInt64())
+			assert.Equal(t, int64(1), point.Value)
+		}
+	})
+}
+
+func TestInstrumentTwice(t *testing.T) {
+
+	t.Run("should not record a response twice", func(t *testing.T) {
+
+		reader := sdkmetric.NewManualReader()
+
+		config := otel.Config{MeterProvider: sdkmetric.NewMeterProvider(sdkmetric.WithReader(reader))}
+
+		assert.NoError(t, otel.Instrument(config))
+		assert.NoError(t, otel.Instrument(config))
+
+		t.Cleanup(rest.ResetWriteHooks)
+
+		rest.Marshalled(httptest.NewRecorder(), []int{1}, http.StatusOK)
+
+		data := metricdata.ResourceMetrics{}
+
+		assert.NoError(t, reader.Collect(context.Background(), &data))
+
+		for _, m := range data.ScopeMetrics[0].Metrics {
+			if m.Name == "rest.responses" {
+				points := m.Data.(metricdata.Sum[int64]).DataPoints
+				assert.Len(t, points, 1)
+				assert.Equal(t, int64(1), points[0].Value)
+			}
+		}
+	})
+}
@@ -0,0 +1,136 @@
+package rest
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+// Page describe a page of a collection, URL is used to build the Link header
+type Page struct {
+	Number  int
+	PerPage int
+	Total   int
+	URL     *url.URL
+}
+
+// pageMeta is the meta of a paginated response
+type pageMeta struct {
+	Total      int `json:"total"`
+	Page       int `json:"page"`
+	PerPage    int `json:"per_page"`
+	TotalPages int `json:"total_pages"`
+}
+
+// paginated is the envelope of a paginated response
+type paginated struct {
+	Data interface{} `json:"data"`
+	Meta pageMeta    `json:"meta"`
+}
+
+// PageFromRequest read the query parameters page and per_page, starting from 1 and limiting
+// per_page between 1 and maxPerPage, defaultPerPage is used when per_page is missing
+func PageFromRequest(r *http.Request, defaultPerPage, maxPerPage int) Page {
+
+	query := r.URL.Query()
+
+	number, err := strconv.Atoi(query.Get("page"))
+
+	if err != nil || number < 1 {
+		number = 1
+	}
+
+	perPage, err := strconv.Atoi(query.Get("per_page"))
+
+	if err != nil || perPage < 1 {
+		perPage = defaultPerPage
+	}
+
+	if perPage > maxPerPage {
+		perPage = maxPerPage
+	}
+
+	return Page{Number: number, PerPage: perPage, URL: r.URL}
+}
+
+// Offset return the index of first item of page, used on queries
+func (p Page) Offset() int {
+	if p.Number < 1 {
+		return 0
+	}
+	return (p.Number - 1) * p.PerPage
+}
+
+// TotalPages return how many pages the collection have
+func (p Page) TotalPages() int {
+	if p.PerPage < 1 {
+		return 0
+	}
+	return (p.Total + p.PerPage - 1) / p.PerPage
+}
+
+// Paginated respond items inside of a envelope {"data": [...], "meta": {...}}, sending the
+// Link header (RFC 5988) with first, prev, next and last pages when page have a URL
+func Paginated(w http.ResponseWriter, items interface{}, page Page, code int) (int, error) {
+
+	if links := page.links(); links != "" {
+		w.Header().Set("Link", links)
+	}
+
+	body := &paginated{
+		Data: items,
+		Meta: pageMeta{
+			Total:      page.Total,
+			Page:       page.Number,
+			PerPage:    page.PerPage,
+			TotalPages: page.TotalPages(),
+		},
+	}
+
+	return newCall(context.Background()).marshalled(w, body, code)
+}
+
+func (p Page) links() string {
+
+	if p.URL == nil {
+		return ""
+	}
+
+	links := make([]string, 0, 4)
+
+	lastPage := p.TotalPages()
+
+	if lastPage < 1 {
+		lastPage = 1
+	}
+
+	links = append(links, p.link(1, "first"))
+
+	if p.Number > 1 {
+		links = append(links, p.link(p.Number-1, "prev"))
+	}
+
+	if p.Number < lastPage {
+		links = append(links, p.link(p.Number+1, "next"))
+	}
+
+	links = append(links, p.link(lastPage, "last"))
+
+	return strings.Join(links, ", ")
+}
+
+func (p Page) link(number int, rel string) string {
+
+	u := *p.URL
+
+	query := u.Query()
+	query.Set("page", strconv.Itoa(number))
+	query.Set("per_page", strconv.Itoa(p.PerPage))
+
+	u.RawQuery = query.Encode()
+
+	return fmt.Sprintf(`<%s>; rel="%s"`, u.String(), rel)
+}
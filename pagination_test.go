@@ -0,0 +1,69 @@
+package rest_test
+
+import (
+	"github.com/edermanoel94/rest-go"
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestPageFromRequest(t *testing.T) {
+
+	testCases := []struct {
+		description string
+		url         string
+		page        int
+		perPage     int
+		offset      int
+	}{
+		{"should use defaults", "/products", 1, 20, 0},
+		{"should read query parameters", "/products?page=3&per_page=10", 3, 10, 20},
+		{"should limit per page", "/products?per_page=1000", 1, 100, 0},
+		{"should ignore invalid values", "/products?page=-1&per_page=abc", 1, 20, 0},
+	}
+
+	for _, tc := range testCases {
+
+		t.Run(tc.description, func(t *testing.T) {
+
+			page := rest.PageFromRequest(httptest.NewRequest(http.MethodGet, tc.url, nil), 20, 100)
+
+			assert.Equal(t, tc.page, page.Number)
+			assert.Equal(t, tc.perPage, page.PerPage)
+			assert.Equal(t, tc.offset, page.Offset())
+		})
+	}
+}
+
+func TestPaginated(t *testing.T) {
+
+	t.Run("should wrap items and send links", func(t *testing.T) {
+
+		request := httptest.NewRequest(http.MethodGet, "http://api.com/products?page=2&per_page=2&sort=name", nil)
+
+		page := rest.PageFromRequest(request, 20, 100)
+		page.Total = 5
+
+		recorder := httptest.NewRecorder()
+
+		rest.Paginated(recorder, []string{"tv", "radio"}, page, http.StatusOK)
+
+		assert.Equal(t, http.StatusOK, recorder.Code)
+		assert.Equal(t, `{"data":["tv","radio"],"meta":{"total":5,"page":2,"per_page":2,"total_pages":3}}`, recorder.Body.String())
+		assert.Equal(t, `<http://api.com/products?page=1&per_page=2&sort=name>; rel="first", `+
+			`<http://api.com/products?page=1&per_page=2&sort=name>; rel="prev", `+
+			`<http://api.com/products?page=3&per_page=2&sort=name>; rel="next", `+
+			`<http://api.com/products?page=3&per_page=2&sort=name>; rel="last"`, recorder.Header().Get("Link"))
+	})
+
+	t.Run("should not send links without url", func(t *testing.T) {
+
+		recorder := httptest.NewRecorder()
+
+		rest.Paginated(recorder, []string{}, rest.Page{Number: 1, PerPage: 10}, http.StatusOK)
+
+		assert.Equal(t, `{"data":[],"meta":{"total":0,"page":1,"per_page":10,"total_pages":0}}`, recorder.Body.String())
+		assert.Empty(t, recorder.Header().Get("Link"))
+	})
+}
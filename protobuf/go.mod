@@ -0,0 +1,20 @@
+module github.com/edermanoel94/rest-go/protobuf
+
+go 1.20
+
+replace github.com/edermanoel94/rest-go => ../
+
+require (
+	github.com/edermanoel94/rest-go v0.0.0-00010101000000-000000000000
+	github.com/stretchr/testify v1.8.3
+	google.golang.org/grpc v1.56.3
+	google.golang.org/protobuf v1.31.0
+)
+
+require (
+	github.com/davecgh/go-spew v1.1.1 // indirect
+	github.com/golang/protobuf v1.5.3 // indirect
+	github.com/pmezard/go-difflib v1.0.0 // indirect
+	google.golang.org/genproto v0.0.0-20230410155749-daa745c078e1 // indirect
+	gopkg.in/yaml.v3 v3.0.1 // indirect
+)
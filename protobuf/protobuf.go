@@ -0,0 +1,38 @@
+// Package protobuf register a application/x-protobuf encoder on rest, importing it is enough
+// to negotiate protobuf for proto.Message values, and Protobuf send it explicitly. Errors can't
+// be encoded on protobuf, they are sent on json. Error send the errors of gRPC status.
+package protobuf
+
+import (
+	"fmt"
+	"github.com/edermanoel94/rest-go"
+	"google.golang.org/protobuf/proto"
+	"net/http"
+)
+
+// MediaType is the content type of protobuf responses
+const MediaType = "application/x-protobuf"
+
+func init() {
+	rest.RegisterEncoder(MediaType, Encoder{})
+}
+
+// Encoder marshal proto.Message values to protobuf, other values are a error
+type Encoder struct{}
+
+// Encode marshal v to protobuf
+func (Encoder) Encode(v interface{}) ([]byte, error) {
+
+	message, ok := v.(proto.Message)
+
+	if !ok {
+		return nil, fmt.Errorf("protobuf: cannot encode %T, must be a proto.Message", v)
+	}
+
+	return proto.Marshal(message)
+}
+
+// Protobuf marshall message and respond application/x-protobuf
+func Protobuf(w http.ResponseWriter, message proto.Message, code int, opts ...rest.Option) (int, error) {
+	return rest.Encoded(w, MediaType, message, code, opts...)
+}
@@ -0,0 +1,54 @@
+package protobuf_test
+
+import (
+	"github.com/edermanoel94/rest-go"
+	"github.com/edermanoel94/rest-go/protobuf"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestProtobuf(t *testing.T) {
+
+	t.Run("should marshal proto message", func(t *testing.T) {
+
+		recorder := httptest.NewRecorder()
+
+		protobuf.Protobuf(recorder, wrapperspb.String("tv"), http.StatusOK)
+
+		decoded := &wrapperspb.StringValue{}
+
+		err := proto.Unmarshal(recorder.Body.Bytes(), decoded)
+
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusOK, recorder.Code)
+		assert.Equal(t, "application/x-protobuf", recorder.Header().Get("Content-Type"))
+		assert.Equal(t, "tv", decoded.GetValue())
+	})
+
+	t.Run("should negotiate protobuf", func(t *testing.T) {
+
+		request := httptest.NewRequest(http.MethodGet, "/products/1", nil)
+		request.Header.Set("Accept", "application/x-protobuf")
+
+		recorder := httptest.NewRecorder()
+
+		rest.Negotiate(recorder, request, wrapperspb.Int64(1), http.StatusOK)
+
+		assert.Equal(t, "application/x-protobuf", recorder.Header().Get("Content-Type"))
+	})
+
+	t.Run("should send error on json when value is not a proto message", func(t *testing.T) {
+
+		recorder := httptest.NewRecorder()
+
+		rest.Encoded(recorder, protobuf.MediaType, map[string]int{"id": 1}, http.StatusOK)
+
+		assert.Equal(t, http.StatusInternalServerError, recorder.Code)
+		assert.Equal(t, "application/json", recorder.Header().Get("Content-Type"))
+		assert.Equal(t, `{"message":"protobuf: cannot encode map[string]int, must be a proto.Message"}`, recorder.Body.String())
+	})
+}
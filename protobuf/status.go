@@ -0,0 +1,102 @@
+package protobuf
+
+import (
+	"github.com/edermanoel94/rest-go"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"net/http"
+	"strings"
+	"unicode"
+)
+
+// httpStatuses is the status of each gRPC code, like grpc-gateway
+var httpStatuses = map[codes.Code]int{
+	codes.OK:                 http.StatusOK,
+	codes.Canceled:           499,
+	codes.Unknown:            http.StatusInternalServerError,
+	codes.InvalidArgument:    http.StatusBadRequest,
+	codes.DeadlineExceeded:   http.StatusGatewayTimeout,
+	codes.NotFound:           http.StatusNotFound,
+	codes.AlreadyExists:      http.StatusConflict,
+	codes.PermissionDenied:   http.StatusForbidden,
+	codes.ResourceExhausted:  http.StatusTooManyRequests,
+	codes.FailedPrecondition: http.StatusBadRequest,
+	codes.Aborted:            http.StatusConflict,
+	codes.OutOfRange:         http.StatusBadRequest,
+	codes.Unimplemented:      http.StatusNotImplemented,
+	codes.Internal:           http.StatusInternalServerError,
+	codes.Unavailable:        http.StatusServiceUnavailable,
+	codes.DataLoss:           http.StatusInternalServerError,
+	codes.Unauthenticated:    http.StatusUnauthorized,
+}
+
+// HTTPStatus return the http status of a gRPC code, a unknown code is 500
+func HTTPStatus(code codes.Code) int {
+
+	if status, ok := httpStatuses[code]; ok {
+		return status
+	}
+
+	return http.StatusInternalServerError
+}
+
+// StatusError is a gRPC status as error, Error respond it with the http status of code
+// and the code on body, like {"message":"product not found","code":"NOT_FOUND"}
+type StatusError struct {
+	Status *status.Status
+}
+
+func (s *StatusError) Error() string {
+	return s.Status.Message()
+}
+
+// StatusCode return the http status of code
+func (s *StatusError) StatusCode() int {
+	return HTTPStatus(s.Status.Code())
+}
+
+// ErrorCode return the name of code, like NOT_FOUND
+func (s *StatusError) ErrorCode() string {
+	return codeName(s.Status.Code())
+}
+
+// GRPCStatus return the status, so status.FromError and status.Code keep working
+func (s *StatusError) GRPCStatus() *status.Status {
+	return s.Status
+}
+
+// FromError return a *StatusError for errors of gRPC status, other errors are returned as they are
+func FromError(err error) error {
+
+	if err == nil {
+		return nil
+	}
+
+	if st, ok := status.FromError(err); ok {
+		return &StatusError{Status: st}
+	}
+
+	return err
+}
+
+// Error send a error of gRPC status with the http status of its code, other errors are sent like rest.Error with code 0
+func Error(w http.ResponseWriter, err error, opts ...rest.Option) (int, error) {
+	return rest.Error(w, FromError(err), 0, opts...)
+}
+
+// codeName return the name of code on upper snake case, like NOT_FOUND for NotFound
+func codeName(code codes.Code) string {
+
+	var name strings.Builder
+
+	for i, r := range code.String() {
+
+		if i > 0 && unicode.IsUpper(r) {
+			name.WriteByte('_')
+		}
+
+		name.WriteRune(unicode.ToUpper(r))
+	}
+
+	return name.String()
+}
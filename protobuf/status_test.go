@@ -0,0 +1,89 @@
+package protobuf_test
+
+import (
+	"errors"
+	"fmt"
+	"github.com/edermanoel94/rest-go/protobuf"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestHTTPStatus(t *testing.T) {
+
+	testCases := []struct {
+		description string
+		code        codes.Code
+		status      int
+	}{
+		{"should map not found", codes.NotFound, http.StatusNotFound},
+		{"should map invalid argument", codes.InvalidArgument, http.StatusBadRequest},
+		{"should map unauthenticated", codes.Unauthenticated, http.StatusUnauthorized},
+		{"should map resource exhausted", codes.ResourceExhausted, http.StatusTooManyRequests},
+		{"should map canceled to client closed request", codes.Canceled, 499},
+		{"should map unknown codes to internal server error", codes.Code(99), http.StatusInternalServerError},
+	}
+
+	for _, tc := range testCases {
+
+		t.Run(tc.description, func(t *testing.T) {
+
+			assert.Equal(t, tc.status, protobuf.HTTPStatus(tc.code))
+		})
+	}
+}
+
+func TestError(t *testing.T) {
+
+	testCases := []struct {
+		description string
+		err         error
+		status      int
+		body        string
+	}{
+		{"should send status of grpc error", status.Error(codes.NotFound, "product not found"),
+			http.StatusNotFound, `{"message":"product not found","code":"NOT_FOUND"}`},
+		{"should send code name on upper snake case", status.Error(codes.FailedPrecondition, "stock is empty"),
+			http.StatusBadRequest, `{"message":"stock is empty","code":"FAILED_PRECONDITION"}`},
+		{"should send other errors like rest", errors.New("broken"),
+			http.StatusInternalServerError, `{"message":"broken"}`},
+	}
+
+	for _, tc := range testCases {
+
+		t.Run(tc.description, func(t *testing.T) {
+
+			recorder := httptest.NewRecorder()
+
+			protobuf.Error(recorder, tc.err)
+
+			assert.Equal(t, tc.status, recorder.Code)
+			assert.Equal(t, tc.body, recorder.Body.String())
+		})
+	}
+}
+
+func TestFromError(t *testing.T) {
+
+	t.Run("should keep the grpc status", func(t *testing.T) {
+
+		err := protobuf.FromError(status.Error(codes.AlreadyExists, "product exists"))
+
+		var statusErr *protobuf.StatusError
+
+		assert.True(t, errors.As(err, &statusErr))
+		assert.Equal(t, http.StatusConflict, statusErr.StatusCode())
+		assert.Equal(t, codes.AlreadyExists, status.Code(err))
+	})
+
+	t.Run("should return other errors as they are", func(t *testing.T) {
+
+		err := fmt.Errorf("broken")
+
+		assert.Equal(t, err, protobuf.FromError(err))
+		assert.Nil(t, protobuf.FromError(nil))
+	})
+}
@@ -0,0 +1,221 @@
+package rest
+
+import (
+	"encoding"
+	"fmt"
+	"net/http"
+	"net/url"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var (
+	timeType            = reflect.TypeOf(time.Time{})
+	durationType        = reflect.TypeOf(time.Duration(0))
+	textUnmarshalerType = reflect.TypeOf((*encoding.TextUnmarshaler)(nil)).Elem()
+)
+
+// BindQuery fill a pointer to struct with the query parameters of request, named by query tag,
+// like `query:"page" default:"1"`. Ints, uints, floats, bools, strings, durations, times on
+// RFC 3339, or on layout of layout tag, encoding.TextUnmarshaler and slices of them are accepted,
+// slices from repeated parameters or separated by comma. Errors are a *BindError with status 400.
+func BindQuery(r *http.Request, v interface{}) error {
+	return bindValues(r.URL.Query(), "query", "query parameter", v)
+}
+
+// BindPath fill a pointer to struct with path variables, like mux.Vars(r), named by path tag,
+// converting them like BindQuery
+func BindPath(params map[string]string, v interface{}) error {
+
+	values := url.Values{}
+
+	for key, value := range params {
+		values.Set(key, value)
+	}
+
+	return bindValues(values, "path", "path variable", v)
+}
+
+func bindValues(values url.Values, tagName, kind string, v interface{}) error {
+
+	value := reflect.ValueOf(v)
+
+	if value.Kind() != reflect.Ptr || value.IsNil() || value.Elem().Kind() != reflect.Struct {
+		return fmt.Errorf("cannot bind %s on %T, must be a pointer to struct", kind, v)
+	}
+
+	return bindStruct(values, tagName, kind, value.Elem())
+}
+
+func bindStruct(values url.Values, tagName, kind string, value reflect.Value) error {
+
+	for i := 0; i < value.NumField(); i++ {
+
+		field := value.Type().Field(i)
+
+		name, ok := field.Tag.Lookup(tagName)
+
+		if !ok && field.Anonymous && field.Type.Kind() == reflect.Struct {
+			if err := bindStruct(values, tagName, kind, value.Field(i)); err != nil {
+				return err
+			}
+			continue
+		}
+
+		if !ok || name == "-" || field.PkgPath != "" {
+			continue
+		}
+
+		raw, found := values[name]
+
+		if !found || len(raw) == 0 || (len(raw) == 1 && raw[0] == "") {
+			defaultValue, hasDefault := field.Tag.Lookup("default")
+			if !hasDefault {
+				continue
+			}
+			raw = []string{defaultValue}
+		}
+
+		if err := setValue(value.Field(i), raw, field.Tag.Get("layout")); err != nil {
+			if _, unsupported := err.(*unsupportedTypeError); unsupported {
+				// a programming error, not a bad request
+				return fmt.Errorf("cannot bind %s %s: %w", kind, name, err)
+			}
+			return &BindError{Status: http.StatusBadRequest,
+				Message: fmt.Sprintf("%s %s %s", kind, name, err.Error())}
+		}
+	}
+
+	return nil
+}
+
+type unsupportedTypeError struct {
+	t reflect.Type
+}
+
+func (u *unsupportedTypeError) Error() string {
+	return fmt.Sprintf("unsupported type %s", u.t)
+}
+
+// setValue convert raw to the type of field, returning a error which complete "query parameter page ..."
+func setValue(field reflect.Value, raw []string, layout string) error {
+
+	if field.Kind() == reflect.Slice && field.Type() != reflect.TypeOf([]byte(nil)) &&
+		!reflect.PtrTo(field.Type()).Implements(textUnmarshalerType) {
+
+		var items []string
+
+		for _, value := range raw {
+			items = append(items, strings.Split(value, ",")...)
+		}
+
+		slice := reflect.MakeSlice(field.Type(), len(items), len(items))
+
+		for i, item := range items {
+			if err := setScalar(slice.Index(i), item, layout); err != nil {
+				return err
+			}
+		}
+
+		field.Set(slice)
+
+		return nil
+	}
+
+	return setScalar(field, raw[0], layout)
+}
+
+func setScalar(field reflect.Value, raw, layout string) error {
+
+	if field.Kind() == reflect.Ptr {
+		value := reflect.New(field.Type().Elem())
+		if err := setScalar(value.Elem(), raw, layout); err != nil {
+			return err
+		}
+		field.Set(value)
+		return nil
+	}
+
+	switch {
+	case field.Type() == timeType:
+
+		if layout == "" {
+			layout = time.RFC3339
+		}
+
+		parsed, err := time.Parse(layout, raw)
+
+		if err != nil {
+			return fmt.Errorf("must be a time on format %s", layout)
+		}
+
+		field.Set(reflect.ValueOf(parsed))
+
+		return nil
+	case field.Type() == durationType:
+
+		parsed, err := time.ParseDuration(raw)
+
+		if err != nil {
+			return fmt.Errorf("must be a duration, like 1h30m")
+		}
+
+		field.SetInt(int64(parsed))
+
+		return nil
+	case reflect.PtrTo(field.Type()).Implements(textUnmarshalerType):
+
+		if err := field.Addr().Interface().(encoding.TextUnmarshaler).UnmarshalText([]byte(raw)); err != nil {
+			return fmt.Errorf("is not valid: %v", err)
+		}
+
+		return nil
+	}
+
+	switch field.Kind() {
+	case reflect.String:
+		field.SetString(raw)
+	case reflect.Bool:
+
+		parsed, err := strconv.ParseBool(raw)
+
+		if err != nil {
+			return fmt.Errorf("must be a bool")
+		}
+
+		field.SetBool(parsed)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+
+		parsed, err := strconv.ParseInt(raw, 10, field.Type().Bits())
+
+		if err != nil {
+			return fmt.Errorf("must be a int")
+		}
+
+		field.SetInt(parsed)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+
+		parsed, err := strconv.ParseUint(raw, 10, field.Type().Bits())
+
+		if err != nil {
+			return fmt.Errorf("must be a positive int")
+		}
+
+		field.SetUint(parsed)
+	case reflect.Float32, reflect.Float64:
+
+		parsed, err := strconv.ParseFloat(raw, field.Type().Bits())
+
+		if err != nil {
+			return fmt.Errorf("must be a number")
+		}
+
+		field.SetFloat(parsed)
+	default:
+		return &unsupportedTypeError{field.Type()}
+	}
+
+	return nil
+}
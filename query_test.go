@@ -0,0 +1,133 @@
+package rest_test
+
+import (
+	"errors"
+	"github.com/edermanoel94/rest-go"
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+type pagination struct {
+	Page    int `query:"page" default:"1"`
+	PerPage int `query:"per_page" default:"20"`
+}
+
+type productFilter struct {
+	pagination
+	Name     string        `query:"name"`
+	InStock  *bool         `query:"in_stock"`
+	MinPrice float64       `query:"min_price"`
+	Tags     []string      `query:"tag"`
+	IDs      []uint        `query:"ids"`
+	Since    time.Time     `query:"since"`
+	Day      time.Time     `query:"day" layout:"2006-01-02"`
+	Timeout  time.Duration `query:"timeout"`
+	Status   string        `query:"status" default:"active"`
+	Ignored  string
+}
+
+func TestBindQuery(t *testing.T) {
+
+	t.Run("should bind query parameters", func(t *testing.T) {
+
+		request := httptest.NewRequest(http.MethodGet,
+			"/products?page=2&name=tv&in_stock=true&min_price=9.9&tag=home&tag=tv&ids=1,2"+
+				"&since=2020-01-02T03:04:05Z&day=2020-05-06&timeout=1m30s&Ignored=x", nil)
+
+		var filter productFilter
+
+		err := rest.BindQuery(request, &filter)
+
+		inStock := true
+
+		assert.NoError(t, err)
+		assert.Equal(t, productFilter{
+			pagination: pagination{Page: 2, PerPage: 20},
+			Name:       "tv",
+			InStock:    &inStock,
+			MinPrice:   9.9,
+			Tags:       []string{"home", "tv"},
+			IDs:        []uint{1, 2},
+			Since:      time.Date(2020, 1, 2, 3, 4, 5, 0, time.UTC),
+			Day:        time.Date(2020, 5, 6, 0, 0, 0, 0, time.UTC),
+			Timeout:    90 * time.Second,
+			Status:     "active",
+		}, filter)
+	})
+
+	testCases := []struct {
+		description string
+		query       string
+		message     string
+	}{
+		{"should fail on a invalid int", "page=two", "query parameter page must be a int"},
+		{"should fail on a invalid bool", "in_stock=maybe", "query parameter in_stock must be a bool"},
+		{"should fail on a negative uint", "ids=1,-2", "query parameter ids must be a positive int"},
+		{"should fail on a invalid time", "day=06/05/2020", "query parameter day must be a time on format 2006-01-02"},
+		{"should fail on a invalid duration", "timeout=soon", "query parameter timeout must be a duration, like 1h30m"},
+	}
+
+	for _, tc := range testCases {
+
+		t.Run(tc.description, func(t *testing.T) {
+
+			request := httptest.NewRequest(http.MethodGet, "/products?"+tc.query, nil)
+
+			var filter productFilter
+
+			err := rest.BindQuery(request, &filter)
+
+			recorder := httptest.NewRecorder()
+
+			rest.Error(recorder, err, 0)
+
+			assert.Equal(t, http.StatusBadRequest, recorder.Code)
+			assert.Equal(t, `{"message":"`+tc.message+`"}`, recorder.Body.String())
+		})
+	}
+
+	t.Run("should fail when is not a pointer to struct", func(t *testing.T) {
+
+		request := httptest.NewRequest(http.MethodGet, "/products", nil)
+
+		var filter productFilter
+
+		err := rest.BindQuery(request, filter)
+
+		var bindErr *rest.BindError
+
+		assert.Error(t, err)
+		assert.False(t, errors.As(err, &bindErr))
+	})
+}
+
+func TestBindPath(t *testing.T) {
+
+	t.Run("should bind path variables", func(t *testing.T) {
+
+		var params struct {
+			ID   int    `path:"id"`
+			Slug string `path:"slug"`
+		}
+
+		err := rest.BindPath(map[string]string{"id": "42", "slug": "tv"}, &params)
+
+		assert.NoError(t, err)
+		assert.Equal(t, 42, params.ID)
+		assert.Equal(t, "tv", params.Slug)
+	})
+
+	t.Run("should fail on a invalid path variable", func(t *testing.T) {
+
+		var params struct {
+			ID int `path:"id"`
+		}
+
+		err := rest.BindPath(map[string]string{"id": "abc"}, &params)
+
+		assert.EqualError(t, err, "path variable id must be a int")
+	})
+}
@@ -0,0 +1,103 @@
+package rest
+
+import (
+	"errors"
+	"math"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+var (
+	ErrRateLimited = errors.New("rate limit exceeded")
+)
+
+// Limit is the state of a rate limit for a client, sent on X-RateLimit headers
+type Limit struct {
+	// Limit is the number of requests allowed on the window
+	Limit int
+	// Remaining is the number of requests left on the window
+	Remaining int
+	// Reset is when the window restart, sent as unix seconds
+	Reset time.Time
+}
+
+// Headers return the X-RateLimit-Limit, X-RateLimit-Remaining and X-RateLimit-Reset headers of limit
+func (l Limit) Headers() http.Header {
+
+	header := make(http.Header)
+
+	header.Set("X-RateLimit-Limit", strconv.Itoa(l.Limit))
+	header.Set("X-RateLimit-Remaining", strconv.Itoa(l.Remaining))
+
+	if !l.Reset.IsZero() {
+		header.Set("X-RateLimit-Reset", strconv.FormatInt(l.Reset.Unix(), 10))
+	}
+
+	return header
+}
+
+// retryAfter return the seconds until reset, rounded up
+func (l Limit) retryAfter() int {
+
+	if l.Reset.IsZero() {
+		return 0
+	}
+
+	seconds := math.Ceil(time.Until(l.Reset).Seconds())
+
+	if seconds < 0 {
+		return 0
+	}
+
+	return int(seconds)
+}
+
+// RateLimitError is the error sent by RateLimited, can be returned to Error which respond 429
+// with Retry-After and the X-RateLimit headers
+type RateLimitError struct {
+	Limit Limit
+}
+
+func (r *RateLimitError) Error() string {
+	return ErrRateLimited.Error()
+}
+
+// Unwrap return ErrRateLimited
+func (r *RateLimitError) Unwrap() error {
+	return ErrRateLimited
+}
+
+// StatusCode make Error respond with 429
+func (r *RateLimitError) StatusCode() int {
+	return http.StatusTooManyRequests
+}
+
+// Headers return the headers of limit and Retry-After
+func (r *RateLimitError) Headers() http.Header {
+
+	header := r.Limit.Headers()
+
+	header.Set("Retry-After", strconv.Itoa(r.Limit.retryAfter()))
+
+	return header
+}
+
+// RateLimited respond 429 as json error, with Retry-After until the reset of limit
+func RateLimited(w http.ResponseWriter, limit Limit, opts ...Option) (int, error) {
+	return Error(w, &RateLimitError{Limit: limit}, http.StatusTooManyRequests, opts...)
+}
+
+// RateLimit send the X-RateLimit headers of limit on response
+func RateLimit(limit Limit) Option {
+	return func(o *options) {
+
+		if o.header == nil {
+			o.header = make(http.Header)
+		}
+
+		for key, values := range limit.Headers() {
+			o.header[key] = values
+		}
+	}
+}
@@ -0,0 +1,78 @@
+package rest_test
+
+import (
+	"errors"
+	"fmt"
+	"github.com/edermanoel94/rest-go"
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestRateLimited(t *testing.T) {
+
+	reset := time.Now().Add(30 * time.Second)
+
+	testCases := []struct {
+		description string
+		limit       rest.Limit
+		retryAfter  string
+		reset       string
+	}{
+		{"should send seconds until reset", rest.Limit{Limit: 100, Remaining: 0, Reset: reset},
+			"30", fmt.Sprint(reset.Unix())},
+		{"should send zero when reset passed", rest.Limit{Limit: 100, Remaining: 0, Reset: time.Now().Add(-time.Minute)},
+			"0", fmt.Sprint(time.Now().Add(-time.Minute).Unix())},
+		{"should send zero without reset", rest.Limit{Limit: 100, Remaining: 0}, "0", ""},
+	}
+
+	for _, tc := range testCases {
+
+		t.Run(tc.description, func(t *testing.T) {
+
+			recorder := httptest.NewRecorder()
+
+			rest.RateLimited(recorder, tc.limit)
+
+			assert.Equal(t, http.StatusTooManyRequests, recorder.Code)
+			assert.Equal(t, `{"message":"rate limit exceeded"}`, recorder.Body.String())
+			assert.Equal(t, tc.retryAfter, recorder.Header().Get("Retry-After"))
+			assert.Equal(t, "100", recorder.Header().Get("X-RateLimit-Limit"))
+			assert.Equal(t, "0", recorder.Header().Get("X-RateLimit-Remaining"))
+			assert.Equal(t, tc.reset, recorder.Header().Get("X-RateLimit-Reset"))
+		})
+	}
+
+	t.Run("should send rate limit error by Error", func(t *testing.T) {
+
+		recorder := httptest.NewRecorder()
+
+		err := fmt.Errorf("login: %w", &rest.RateLimitError{Limit: rest.Limit{Limit: 5}})
+
+		rest.Error(recorder, err, 0)
+
+		assert.True(t, errors.Is(err, rest.ErrRateLimited))
+		assert.Equal(t, http.StatusTooManyRequests, recorder.Code)
+		assert.Equal(t, "5", recorder.Header().Get("X-RateLimit-Limit"))
+	})
+}
+
+func TestRateLimit(t *testing.T) {
+
+	t.Run("should send rate limit headers on success", func(t *testing.T) {
+
+		reset := time.Date(2030, 1, 2, 3, 4, 5, 0, time.UTC)
+
+		recorder := httptest.NewRecorder()
+
+		rest.Marshalled(recorder, []int{1}, http.StatusOK, rest.RateLimit(rest.Limit{Limit: 100, Remaining: 99, Reset: reset}))
+
+		assert.Equal(t, http.StatusOK, recorder.Code)
+		assert.Equal(t, "100", recorder.Header().Get("X-RateLimit-Limit"))
+		assert.Equal(t, "99", recorder.Header().Get("X-RateLimit-Remaining"))
+		assert.Equal(t, "1893553445", recorder.Header().Get("X-RateLimit-Reset"))
+		assert.Empty(t, recorder.Header().Get("Retry-After"))
+	})
+}
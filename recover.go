@@ -0,0 +1,43 @@
+package rest
+
+import (
+	"errors"
+	"log"
+	"net/http"
+	"runtime/debug"
+)
+
+var (
+	ErrInternalServer = errors.New("internal server error")
+)
+
+// Recover is a middleware which recover panics of handlers, logging the stack and sending
+// a 500 json error when the response was not started yet
+func Recover(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+
+		rw := newResponseWriter(w)
+
+		defer func() {
+
+			recovered := recover()
+
+			if recovered == nil {
+				return
+			}
+
+			// the server abort the response silently
+			if recovered == http.ErrAbortHandler {
+				panic(recovered)
+			}
+
+			log.Printf("rest: panic serving %s %s: %v\n%s", r.Method, r.URL.Path, recovered, debug.Stack())
+
+			if !rw.wroteHeader {
+				newCall(r.Context()).fail(rw, ErrInternalServer, http.StatusInternalServerError)
+			}
+		}()
+
+		next.ServeHTTP(expose(rw, w), r)
+	})
+}
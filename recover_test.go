@@ -0,0 +1,80 @@
+package rest_test
+
+import (
+	"bytes"
+	"github.com/edermanoel94/rest-go"
+	"github.com/stretchr/testify/assert"
+	"log"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"testing"
+)
+
+func TestRecover(t *testing.T) {
+
+	output := &bytes.Buffer{}
+
+	log.SetOutput(output)
+
+	defer log.SetOutput(os.Stderr)
+
+	t.Run("should send a json error on panic", func(t *testing.T) {
+
+		handler := rest.Recover(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			panic("something bad")
+		}))
+
+		recorder := httptest.NewRecorder()
+
+		handler.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/products", nil))
+
+		assert.Equal(t, http.StatusInternalServerError, recorder.Code)
+		assert.Equal(t, "application/json", recorder.Header().Get("Content-Type"))
+		assert.Equal(t, `{"message":"internal server error"}`, recorder.Body.String())
+		assert.Contains(t, output.String(), "panic serving GET /products: something bad")
+		assert.Contains(t, output.String(), "goroutine")
+	})
+
+	t.Run("should not write after response started", func(t *testing.T) {
+
+		handler := rest.Recover(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			rest.Marshalled(w, map[string]int{"id": 1}, http.StatusOK)
+			panic("something bad")
+		}))
+
+		recorder := httptest.NewRecorder()
+
+		handler.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/", nil))
+
+		assert.Equal(t, http.StatusOK, recorder.Code)
+		assert.Equal(t, `{"id":1}`, recorder.Body.String())
+	})
+
+	t.Run("should not recover abort handler", func(t *testing.T) {
+
+		handler := rest.Recover(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			panic(http.ErrAbortHandler)
+		}))
+
+		assert.PanicsWithValue(t, http.ErrAbortHandler, func() {
+			handler.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/", nil))
+		})
+	})
+}
+
+func TestRecoverWithoutFlusher(t *testing.T) {
+
+	t.Run("should not report flush when wrapped writer can't flush", func(t *testing.T) {
+
+		var flushable bool
+
+		handler := rest.Recover(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			flushable = rest.Chunked(w).Flushable()
+		}))
+
+		handler.ServeHTTP(struct{ http.ResponseWriter }{httptest.NewRecorder()}, httptest.NewRequest(http.MethodGet, "/", nil))
+
+		assert.False(t, flushable)
+	})
+}
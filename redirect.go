@@ -0,0 +1,25 @@
+package rest
+
+import (
+	"net/http"
+)
+
+const textHtml = "text/html"
+
+// Redirect respond a redirect to url, browsers which accept text/html receive the html of
+// http.Redirect and other clients receive {"location":url}
+func Redirect(w http.ResponseWriter, r *http.Request, url string, code int, opts ...Option) (int, error) {
+
+	w.Header().Add("Vary", accept)
+
+	c := newCall(r.Context(), append(opts, Location(url))...)
+
+	if negotiateMediaType(r.Header.Get(accept), applicationJson, textHtml) == textHtml {
+		rw := newResponseWriter(w)
+		http.Redirect(rw, r, url, code)
+		c.notify(w.Header().Get(contentType), rw.status, int(rw.written), rw.err)
+		return int(rw.written), rw.err
+	}
+
+	return c.marshalled(w, &locationBody{Location: url}, code)
+}
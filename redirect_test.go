@@ -0,0 +1,65 @@
+package rest_test
+
+import (
+	"github.com/edermanoel94/rest-go"
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestRedirect(t *testing.T) {
+
+	testCases := []struct {
+		description string
+		accept      string
+		contentType string
+		body        string
+	}{
+		{"should send json to clients without accept", "", "application/json", `{"location":"/products/2"}`},
+		{"should send json to json clients", "application/json", "application/json", `{"location":"/products/2"}`},
+		{"should send html to browsers", "text/html,application/xhtml+xml,*/*;q=0.8", "text/html; charset=utf-8",
+			"<a href=\"/products/2\">Moved Permanently</a>.\n\n"},
+	}
+
+	for _, tc := range testCases {
+
+		t.Run(tc.description, func(t *testing.T) {
+
+			request := httptest.NewRequest(http.MethodGet, "/products/1", nil)
+			request.Header.Set("Accept", tc.accept)
+
+			recorder := httptest.NewRecorder()
+
+			rest.Redirect(recorder, request, "/products/2", http.StatusMovedPermanently)
+
+			assert.Equal(t, http.StatusMovedPermanently, recorder.Code)
+			assert.Equal(t, "/products/2", recorder.Header().Get("Location"))
+			assert.Equal(t, "Accept", recorder.Header().Get("Vary"))
+			assert.Equal(t, tc.contentType, recorder.Header().Get("Content-Type"))
+			assert.Equal(t, tc.body, recorder.Body.String())
+		})
+	}
+}
+
+func TestRedirectWithBrokenWriter(t *testing.T) {
+
+	t.Run("should return the error of writer on html", func(t *testing.T) {
+
+		var events []rest.Event
+
+		rest.OnWrite(func(event rest.Event) {
+			events = append(events, event)
+		})
+		defer rest.ResetWriteHooks()
+
+		request := httptest.NewRequest(http.MethodGet, "/products/1", nil)
+		request.Header.Set("Accept", "text/html")
+
+		_, err := rest.Redirect(brokenWriter{httptest.NewRecorder()}, request, "/products/2", http.StatusMovedPermanently)
+
+		assert.EqualError(t, err, "broken pipe")
+		assert.Equal(t, http.StatusMovedPermanently, events[0].Status)
+		assert.Equal(t, err, events[0].WriteErr)
+	})
+}
@@ -1,10 +1,13 @@
 package rest
 
 import (
+	"bytes"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"io/ioutil"
+	"net/http"
 	"strings"
 )
 
@@ -49,11 +52,144 @@ func GetBody(reader io.ReadCloser, result interface{}) error {
 	}
 
 	// TODO can do better performance
-	err = json.Unmarshal(bytes, result)
+	err = unmarshalJson(bytes, result)
 
 	if err != nil {
-		return fmt.Errorf("couldn't unmarshal: %v", err)
+		return fmt.Errorf("couldn't unmarshal: %w", err)
 	}
 
 	return nil
 }
+
+// DefaultMaxBodyBytes is the size limit of body read by Bind
+const DefaultMaxBodyBytes int64 = 1 << 20
+
+// BindError is returned by Bind, rendered by Error with its status code
+type BindError struct {
+	Status  int
+	Message string
+	Err     error
+}
+
+func (b *BindError) Error() string {
+	if b.Err != nil {
+		return fmt.Sprintf("%s: %v", b.Message, b.Err)
+	}
+	return b.Message
+}
+
+// Unwrap return the cause of error
+func (b *BindError) Unwrap() error {
+	return b.Err
+}
+
+// StatusCode make Error respond with the status of failure, usually 400
+func (b *BindError) StatusCode() int {
+	return b.Status
+}
+
+// BindOption customize how Bind decode the body
+type BindOption func(*bindOptions)
+
+type bindOptions struct {
+	maxBodyBytes          int64
+	disallowUnknownFields bool
+}
+
+// MaxBodyBytes change the size limit of body, DefaultMaxBodyBytes is used by default
+func MaxBodyBytes(n int64) BindOption {
+	return func(o *bindOptions) {
+		o.maxBodyBytes = n
+	}
+}
+
+// DisallowUnknownFields make Bind fail when body have a field which don't exist on result
+func DisallowUnknownFields() BindOption {
+	return func(o *bindOptions) {
+		o.disallowUnknownFields = true
+	}
+}
+
+// Bind decode the json body of request on a pointer, the Content-Type must be json and
+// the errors returned are a *BindError, which Error respond with a useful message.
+// When SetMarshaler replaced encoding/json, the body is decoded by it and DisallowUnknownFields is ignored.
+func Bind(r *http.Request, v interface{}, opts ...BindOption) error {
+
+	options := bindOptions{maxBodyBytes: DefaultMaxBodyBytes}
+
+	for _, opt := range opts {
+		opt(&options)
+	}
+
+	if !isJsonMediaType(r.Header.Get(contentType)) {
+		return &BindError{Status: http.StatusUnsupportedMediaType, Message: "content type must be application/json"}
+	}
+
+	if r.Body == nil || r.Body == http.NoBody {
+		return &BindError{Status: http.StatusBadRequest, Message: "request body is empty"}
+	}
+
+	defer r.Body.Close()
+
+	body, err := ioutil.ReadAll(io.LimitReader(r.Body, options.maxBodyBytes+1))
+
+	if err != nil {
+		return &BindError{Status: http.StatusBadRequest, Message: "couldn't read body of request", Err: err}
+	}
+
+	if int64(len(body)) > options.maxBodyBytes {
+		return &BindError{Status: http.StatusRequestEntityTooLarge,
+			Message: fmt.Sprintf("request body must not be larger than %d bytes", options.maxBodyBytes)}
+	}
+
+	if customMarshaler() {
+		if err := unmarshalJson(body, v); err != nil {
+			return bindError(err)
+		}
+		return nil
+	}
+
+	decoder := json.NewDecoder(bytes.NewReader(body))
+
+	if options.disallowUnknownFields {
+		decoder.DisallowUnknownFields()
+	}
+
+	if err := decoder.Decode(v); err != nil {
+		return bindError(err)
+	}
+
+	if decoder.More() {
+		return &BindError{Status: http.StatusBadRequest, Message: "request body must have a single json value"}
+	}
+
+	return nil
+}
+
+func bindError(err error) error {
+
+	var syntaxErr *json.SyntaxError
+	var typeErr *json.UnmarshalTypeError
+	var invalidErr *json.InvalidUnmarshalError
+
+	switch {
+	case errors.Is(err, io.EOF):
+		return &BindError{Status: http.StatusBadRequest, Message: "request body is empty"}
+	case errors.Is(err, io.ErrUnexpectedEOF):
+		return &BindError{Status: http.StatusBadRequest, Message: "request body have a malformed json"}
+	case errors.As(err, &syntaxErr):
+		return &BindError{Status: http.StatusBadRequest,
+			Message: fmt.Sprintf("request body have a malformed json at position %d", syntaxErr.Offset)}
+	case errors.As(err, &typeErr):
+		return &BindError{Status: http.StatusBadRequest,
+			Message: fmt.Sprintf("request body have a invalid value for field %s, expected %s", typeErr.Field, typeErr.Type)}
+	case strings.HasPrefix(err.Error(), "json: unknown field "):
+		field := strings.Trim(strings.TrimPrefix(err.Error(), "json: unknown field "), "\"")
+		return &BindError{Status: http.StatusBadRequest, Message: fmt.Sprintf("request body have unknown field %s", field)}
+	case errors.As(err, &invalidErr):
+		// a programming error, not a bad request
+		return err
+	}
+
+	return &BindError{Status: http.StatusBadRequest, Message: "couldn't decode request body", Err: err}
+}
@@ -0,0 +1,56 @@
+package rest_test
+
+import (
+	"errors"
+	"fmt"
+	"github.com/edermanoel94/rest-go"
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestRequestID(t *testing.T) {
+
+	handler := rest.RequestID(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		rest.ErrorCtx(r.Context(), w, errors.New("not found"), http.StatusNotFound)
+	}))
+
+	t.Run("should propagate the request id of header", func(t *testing.T) {
+
+		request := httptest.NewRequest(http.MethodGet, "/", nil)
+		request.Header.Set("X-Request-ID", "abc-123")
+
+		recorder := httptest.NewRecorder()
+
+		handler.ServeHTTP(recorder, request)
+
+		assert.Equal(t, http.StatusNotFound, recorder.Code)
+		assert.Equal(t, "abc-123", recorder.Header().Get("X-Request-ID"))
+		assert.Equal(t, `{"message":"not found","request_id":"abc-123"}`, recorder.Body.String())
+	})
+
+	t.Run("should generate a request id", func(t *testing.T) {
+
+		recorder := httptest.NewRecorder()
+
+		handler.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/", nil))
+
+		id := recorder.Header().Get("X-Request-ID")
+
+		assert.Len(t, id, 32)
+		assert.Equal(t, fmt.Sprintf(`{"message":"not found","request_id":"%s"}`, id), recorder.Body.String())
+	})
+
+	t.Run("should send the request id attached to context without middleware", func(t *testing.T) {
+
+		ctx := rest.WithRequestID(httptest.NewRequest(http.MethodGet, "/", nil).Context(), "xyz")
+
+		recorder := httptest.NewRecorder()
+
+		rest.ErrorCtx(ctx, recorder, errors.New("conflict"), http.StatusConflict)
+
+		assert.Equal(t, "xyz", recorder.Header().Get("X-Request-ID"))
+		assert.Equal(t, `{"message":"conflict","request_id":"xyz"}`, recorder.Body.String())
+	})
+}
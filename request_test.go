@@ -5,6 +5,8 @@ import (
 	"github.com/edermanoel94/rest-go"
 	"github.com/stretchr/testify/assert"
 	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
 	"strings"
 	"testing"
 )
@@ -122,3 +124,75 @@ func TestGetPathVariable(t *testing.T) {
 		assert.Equal(t, key, "")
 	})
 }
+
+func TestBind(t *testing.T) {
+
+	type product struct {
+		Name  string  `json:"name"`
+		Price float64 `json:"price"`
+	}
+
+	testCases := []struct {
+		description string
+		contentType string
+		body        string
+		opts        []rest.BindOption
+		statusCode  int
+		message     string
+	}{
+		{"should reject a content type which is not json", "text/plain", `{"name":"tv"}`, nil,
+			http.StatusUnsupportedMediaType, "content type must be application/json"},
+		{"should reject a empty body", "application/json", "", nil,
+			http.StatusBadRequest, "request body is empty"},
+		{"should reject a malformed json", "application/json", `{"name":`, nil,
+			http.StatusBadRequest, "request body have a malformed json"},
+		{"should reject a syntax error", "application/json", `{"name" "tv"}`, nil,
+			http.StatusBadRequest, "request body have a malformed json at position 9"},
+		{"should reject a invalid type", "application/json", `{"price":"free"}`, nil,
+			http.StatusBadRequest, "request body have a invalid value for field price, expected float64"},
+		{"should reject unknown fields when disallowed", "application/json", `{"color":"black"}`,
+			[]rest.BindOption{rest.DisallowUnknownFields()}, http.StatusBadRequest, "request body have unknown field color"},
+		{"should reject a body larger than limit", "application/json", `{"name":"smart tv"}`,
+			[]rest.BindOption{rest.MaxBodyBytes(10)}, http.StatusRequestEntityTooLarge, "request body must not be larger than 10 bytes"},
+		{"should reject many json values", "application/json", `{"name":"tv"}{"name":"tv"}`, nil,
+			http.StatusBadRequest, "request body must have a single json value"},
+	}
+
+	for _, tc := range testCases {
+
+		t.Run(tc.description, func(t *testing.T) {
+
+			request := httptest.NewRequest(http.MethodPost, "/", strings.NewReader(tc.body))
+			request.Header.Set("Content-Type", tc.contentType)
+
+			err := rest.Bind(request, &product{}, tc.opts...)
+
+			if err == nil {
+				t.Fatal("expected a error")
+			}
+
+			recorder := httptest.NewRecorder()
+
+			rest.Error(recorder, err, http.StatusInternalServerError)
+
+			assert.Equal(t, tc.statusCode, recorder.Code)
+			assert.Equal(t, fmt.Sprintf(`{"message":"%s"}`, tc.message), recorder.Body.String())
+		})
+	}
+
+	t.Run("should decode a json body", func(t *testing.T) {
+
+		request := httptest.NewRequest(http.MethodPost, "/", strings.NewReader(`{"name":"tv","price":20.5,"color":"black"}`))
+		request.Header.Set("Content-Type", "application/json; charset=utf-8")
+
+		result := product{}
+
+		err := rest.Bind(request, &result)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, product{Name: "tv", Price: 20.5}, result)
+	})
+}
@@ -1,51 +1,242 @@
 package rest
 
 import (
-	"encoding/json"
+	"context"
 	"errors"
 	"net/http"
 	"reflect"
+	"time"
 )
 
 var (
 	ErrNotValidJson = errors.New("not a valid json")
 )
 
-// Response send slice of bytes to respond json
-func Response(w http.ResponseWriter, body []byte, code int) (int, error) {
-	if !json.Valid(body) {
-		return response(w, defaultJsonErrorMessage(ErrNotValidJson), http.StatusInternalServerError)
+// StatusCoder is implemented by errors which carry the status code to respond, Error use
+// StatusCode instead of the code given
+type StatusCoder interface {
+	StatusCode() int
+}
+
+// Headerer is implemented by errors which carry headers to respond, like Retry-After
+type Headerer interface {
+	Headers() http.Header
+}
+
+// Response send slice of bytes to respond json, options can customize it like MaxAge
+func Response(w http.ResponseWriter, body []byte, code int, opts ...Option) (int, error) {
+	return newCall(context.Background(), opts...).content(w, body, code)
+}
+
+// Marshalled use pointer to marshall and respond json, options can customize it like MaxAge.
+// The value is encoded before the status is sent, so a marshal error respond a 500 json error.
+func Marshalled(w http.ResponseWriter, v interface{}, code int, opts ...Option) (int, error) {
+	return newCall(context.Background(), opts...).marshalled(w, v, code)
+}
+
+// ResponseCtx send slice of bytes like Response, but nothing is sent when the context is canceled,
+// like when the client is gone, and context.Canceled is returned
+func ResponseCtx(ctx context.Context, w http.ResponseWriter, body []byte, code int, opts ...Option) (int, error) {
+	return newCall(ctx, opts...).content(w, body, code)
+}
+
+// MarshalledCtx marshall v like Marshalled, but v is not marshalled when the context is canceled,
+// like when the client is gone, and context.Canceled is returned
+func MarshalledCtx(ctx context.Context, w http.ResponseWriter, v interface{}, code int, opts ...Option) (int, error) {
+	return newCall(ctx, opts...).marshalled(w, v, code)
+}
+
+// Error send a error to respond json, can send a non-struct which implements error.
+// When the error implements StatusCoder, its status is used instead of code, and when
+// code is 0 the status is found on DefaultErrorMapper, see MapError. When the error
+// implements Headerer, its headers are sent too. A context.DeadlineExceeded is sent as 504
+// instead of 500.
+func Error(w http.ResponseWriter, err error, code int, opts ...Option) (int, error) {
+	return newCall(context.Background(), opts...).fail(w, err, code)
+}
+
+// ErrorCtx send a error like Error, translating localized errors and code errors to the language
+// on context, set by Localize from Accept-Language, and sending the request id of context on
+// X-Request-ID header and request_id field. Nothing is sent when the context is canceled.
+func ErrorCtx(ctx context.Context, w http.ResponseWriter, err error, code int, opts ...Option) (int, error) {
+
+	c := newCall(ctx, opts...)
+
+	code = DefaultErrorMapper.resolve(err, code)
+
+	var localized *localizedError
+	var coded *CodeError
+
+	// localized errors are translated with the body, keeping the error wrapping them
+	if !errors.As(err, &localized) && errors.As(err, &coded) {
+		err = coded.translated(ctx)
 	}
-	return response(w, body, code)
+
+	return c.writeError(w, err, code)
 }
 
-// Marshalled use pointer to marshall and respond json
-func Marshalled(w http.ResponseWriter, v interface{}, code int) (int, error) {
-	bytes, err := json.Marshal(v)
+// call keep the state of a write helper, from the call until the body is written
+type call struct {
+	ctx   context.Context
+	start time.Time
+	opts  options
+	// err is the error rendered, when the helper send a error
+	err error
+	// errorType is the media type of errors, application/json unless the helper send other format
+	errorType string
+	// encoded is when the body was ready to write
+	encoded time.Time
+	// last is the WriterFunc called after interceptors, writeReply unless the helper change it
+	last WriterFunc
+}
+
+func newCall(ctx context.Context, opts ...Option) *call {
+	return &call{ctx: ctx, start: time.Now(), opts: newOptions(opts), errorType: applicationJson, last: writeReply}
+}
+
+// record keep the first error of call, which is the cause of the others
+func (c *call) record(err error) {
+	if c.err == nil {
+		c.err = err
+	}
+}
+
+func (c *call) content(w http.ResponseWriter, body []byte, code int) (int, error) {
+	if !valid(applicationJson, body) {
+		c.record(ErrNotValidJson)
+		return c.write(w, applicationJson, defaultJsonErrorMessage(c.ctx, ErrNotValidJson, http.StatusInternalServerError), http.StatusInternalServerError)
+	}
+	return c.write(w, applicationJson, c.opts.indentJson(body), code)
+}
+
+// canceled report if the context of call is canceled, so the body don't need to be sent
+func (c *call) canceled() bool {
+	return c.ctx != nil && errors.Is(c.ctx.Err(), context.Canceled)
+}
+
+// abort give up the write, a hook is notified with the error of context
+func (c *call) abort() (int, error) {
+	err := c.ctx.Err()
+	c.record(err)
+	c.notify("", 0, 0, nil)
+	return 0, err
+}
+
+func (c *call) marshalled(w http.ResponseWriter, v interface{}, code int) (int, error) {
+
+	if c.canceled() {
+		return c.abort()
+	}
+
+	if c.opts.emptyCollections {
+		v = c.opts.shaper().shape(reflect.ValueOf(v))
+	}
+
+	enc, ok := lookupEncoder(applicationJson)
+
+	if limit, policy := c.opts.sizeLimit(); limit > 0 && ok {
+		if items, isSlice := sliceItems(v); isSlice {
+			return c.marshalledSlice(w, enc, items, code, limit, policy)
+		}
+	}
+
+	if buffered, isBuffered := enc.(BufferEncoder); ok && isBuffered {
+
+		buf := getBuffer()
+		defer putBuffer(buf)
+
+		if err := buffered.EncodeTo(buf, v); err != nil {
+			return c.fail(w, err, http.StatusInternalServerError)
+		}
+
+		return c.write(w, applicationJson, c.opts.indentJson(buf.Bytes()), code)
+	}
+
+	bytes, err := encode(applicationJson, v)
 	if err != nil {
-		return Error(w, err, http.StatusInternalServerError)
+		return c.fail(w, err, http.StatusInternalServerError)
 	}
-	return Response(w, bytes, code)
+	return c.content(w, bytes, code)
 }
 
-// Error send a error to respond json, can send a non-struct which implements error.
-func Error(w http.ResponseWriter, err error, code int) (int, error) {
+// fail send a error resolving the status with DefaultErrorMapper
+func (c *call) fail(w http.ResponseWriter, err error, code int) (int, error) {
+	return c.writeError(w, err, DefaultErrorMapper.resolve(err, code))
+}
 
-	var errBytes []byte
+// writeError send a error with the status already resolved
+func (c *call) writeError(w http.ResponseWriter, err error, code int) (int, error) {
+
+	c.record(err)
+
+	var headerer Headerer
+
+	if errors.As(err, &headerer) {
+		for key, values := range headerer.Headers() {
+			w.Header()[http.CanonicalHeaderKey(key)] = values
+		}
+	}
+
+	// errors which are not pointers send err.Error() as body with 500, unless they are a multi
+	// error, a StatusCoder or a context.DeadlineExceeded, which keep the status resolved
+	_, multi := multiErrors(err)
+	_, coder := err.(StatusCoder)
+	raw := reflect.TypeOf(err).Kind() != reflect.Ptr && !multi && !coder && !errors.Is(err, context.DeadlineExceeded)
+
+	if raw {
+		code = http.StatusInternalServerError
+	}
+
+	if c.errorType != applicationJson {
+		mediaType, errBytes := errorMessageOf(c.ctx, c.errorType, err, code)
+		return c.write(w, mediaType, errBytes, code)
+	}
 
-	switch typeOf := reflect.TypeOf(err); typeOf.Kind() {
-	case reflect.Ptr:
-		errBytes = defaultJsonErrorMessage(err)
-	default:
-		errBytes = []byte(err.Error())
-		return Response(w, errBytes, http.StatusInternalServerError)
+	if raw {
+		return c.content(w, []byte(err.Error()), code)
 	}
 
-	return Response(w, errBytes, code)
+	return c.content(w, defaultJsonErrorMessage(c.ctx, err, code), code)
 }
 
-func response(w http.ResponseWriter, body []byte, code int) (int, error) {
-	w.Header().Set(contentType, applicationJson)
-	w.WriteHeader(code)
-	return w.Write(body)
+func (c *call) write(w http.ResponseWriter, mediaType string, body []byte, code int) (int, error) {
+
+	if c.canceled() {
+		return c.abort()
+	}
+
+	body, err := applyTransformers(c.ctx, mediaType, body)
+
+	if err != nil {
+		c.record(err)
+		mediaType, body, code = applicationJson, defaultJsonErrorMessage(c.ctx, err, http.StatusInternalServerError), http.StatusInternalServerError
+	}
+
+	if limit, _ := c.opts.sizeLimit(); limit > 0 && len(body) > limit && c.err == nil {
+		err := tooLarge(limit)
+		c.record(err)
+		mediaType, body, code = applicationJson, defaultJsonErrorMessage(c.ctx, err, http.StatusInternalServerError), http.StatusInternalServerError
+	}
+
+	applyDefaultHeaders(w.Header())
+
+	if id := RequestIDFromContext(c.ctx); id != "" && w.Header().Get(RequestIDHeader) == "" {
+		w.Header().Set(RequestIDHeader, id)
+	}
+
+	c.opts.apply(w.Header(), code)
+
+	if c.err == nil {
+		mediaType = c.opts.mediaType(mediaType)
+	}
+
+	c.encoded = time.Now()
+
+	reply := &Reply{Context: c.ctx, Status: code, ContentType: mediaType, Body: body, Err: c.err}
+
+	n, err := intercepted(c.last)(w, reply)
+
+	c.notify(reply.ContentType, reply.Status, n, err)
+
+	return n, err
 }
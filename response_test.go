@@ -1,6 +1,7 @@
 package rest_test
 
 import (
+	"context"
 	"encoding/json"
 	"errors"
 	"fmt"
@@ -10,6 +11,7 @@ import (
 	"net/http"
 	"net/http/httptest"
 	"testing"
+	"time"
 )
 
 type customError struct {
@@ -151,3 +153,239 @@ func TestMarshalled(t *testing.T) {
 		})
 	}
 }
+
+type unavailableError struct{}
+
+func (unavailableError) Error() string {
+	return "try again later"
+}
+
+func (unavailableError) StatusCode() int {
+	return http.StatusServiceUnavailable
+}
+
+func (unavailableError) Headers() http.Header {
+	return http.Header{"retry-after": []string{"120"}}
+}
+
+type notFoundError struct{}
+
+func (notFoundError) Error() string {
+	return "not found"
+}
+
+func (notFoundError) StatusCode() int {
+	return http.StatusNotFound
+}
+
+type forbiddenError struct {
+	reason string
+}
+
+func (f *forbiddenError) Error() string {
+	return f.reason
+}
+
+func (f *forbiddenError) StatusCode() int {
+	return http.StatusForbidden
+}
+
+func TestErrorWithStatusCoder(t *testing.T) {
+
+	testCases := []struct {
+		description string
+		err         error
+		code        int
+		statusCode  int
+		body        string
+		retryAfter  string
+	}{
+		{"should use the status of error instead of code", &forbiddenError{"not allowed"}, http.StatusBadRequest,
+			http.StatusForbidden, `{"message":"not allowed"}`, ""},
+		{"should derive the status when code is 0", fmt.Errorf("%w", &forbiddenError{"not allowed"}), 0,
+			http.StatusForbidden, `{"message":"not allowed"}`, ""},
+		{"should send the headers of error", unavailableError{}, 0,
+			http.StatusServiceUnavailable, `{"message":"try again later"}`, "120"},
+		{"should send a value error with plain text on envelope", notFoundError{}, http.StatusBadRequest,
+			http.StatusNotFound, `{"message":"not found"}`, ""},
+	}
+
+	for _, tc := range testCases {
+
+		t.Run(tc.description, func(t *testing.T) {
+
+			recorder := httptest.NewRecorder()
+
+			rest.Error(recorder, tc.err, tc.code)
+
+			assert.Equal(t, tc.statusCode, recorder.Code)
+			assert.Equal(t, tc.body, recorder.Body.String())
+			assert.Equal(t, tc.retryAfter, recorder.Header().Get("Retry-After"))
+		})
+	}
+}
+
+type brokenMarshaler struct{}
+
+func (brokenMarshaler) MarshalJSON() ([]byte, error) {
+	return nil, errors.New("broken")
+}
+
+func TestMarshalledWithMarshalError(t *testing.T) {
+
+	t.Run("should respond internal server error instead of the status given", func(t *testing.T) {
+
+		recorder := httptest.NewRecorder()
+
+		rest.Marshalled(recorder, []interface{}{1, brokenMarshaler{}}, http.StatusOK)
+
+		assert.Equal(t, http.StatusInternalServerError, recorder.Code)
+		assert.Equal(t, "application/json", recorder.Header().Get("Content-Type"))
+		assert.True(t, json.Valid(recorder.Body.Bytes()))
+		assert.Contains(t, recorder.Body.String(), "broken")
+	})
+
+	t.Run("should not send a partial body", func(t *testing.T) {
+
+		recorder := httptest.NewRecorder()
+
+		rest.Marshalled(recorder, map[string]interface{}{"a": 1, "b": brokenMarshaler{}}, http.StatusOK)
+
+		assert.NotContains(t, recorder.Body.String(), `"a"`)
+	})
+
+	t.Run("should not send cache and content type options of success", func(t *testing.T) {
+
+		recorder := httptest.NewRecorder()
+
+		rest.Marshalled(recorder, brokenMarshaler{}, http.StatusOK,
+			rest.MaxAge(time.Minute), rest.WithContentType("application/vnd.api+json"))
+
+		assert.Equal(t, http.StatusInternalServerError, recorder.Code)
+		assert.Empty(t, recorder.Header().Get("Cache-Control"))
+		assert.Equal(t, "application/json", recorder.Header().Get("Content-Type"))
+	})
+
+	t.Run("should respond internal server error for unsupported values", func(t *testing.T) {
+
+		recorder := httptest.NewRecorder()
+
+		rest.Marshalled(recorder, make(chan int), http.StatusCreated)
+
+		assert.Equal(t, http.StatusInternalServerError, recorder.Code)
+		assert.True(t, json.Valid(recorder.Body.Bytes()))
+	})
+}
+
+type countingValue struct {
+	marshals *int
+}
+
+func (c countingValue) MarshalJSON() ([]byte, error) {
+	*c.marshals++
+	return []byte(`{}`), nil
+}
+
+func TestCanceledContext(t *testing.T) {
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	t.Run("should not marshal when context is canceled", func(t *testing.T) {
+
+		marshals := 0
+
+		recorder := httptest.NewRecorder()
+
+		n, err := rest.MarshalledCtx(ctx, recorder, countingValue{&marshals}, http.StatusOK)
+
+		assert.Equal(t, 0, n)
+		assert.True(t, errors.Is(err, context.Canceled))
+		assert.Equal(t, 0, marshals)
+		assert.False(t, recorder.Flushed)
+		assert.Equal(t, 0, recorder.Body.Len())
+	})
+
+	t.Run("should not send body when context is canceled", func(t *testing.T) {
+
+		recorder := httptest.NewRecorder()
+
+		_, err := rest.ResponseCtx(ctx, recorder, []byte(`{"id":1}`), http.StatusOK)
+
+		assert.True(t, errors.Is(err, context.Canceled))
+		assert.Equal(t, 0, recorder.Body.Len())
+	})
+
+	t.Run("should not send error when context is canceled", func(t *testing.T) {
+
+		recorder := httptest.NewRecorder()
+
+		_, err := rest.ErrorCtx(ctx, recorder, errors.New("not found"), http.StatusNotFound)
+
+		assert.True(t, errors.Is(err, context.Canceled))
+		assert.Equal(t, 0, recorder.Body.Len())
+	})
+
+	t.Run("should notify hooks when context is canceled", func(t *testing.T) {
+
+		var events []rest.Event
+
+		rest.OnWrite(func(event rest.Event) {
+			events = append(events, event)
+		})
+		defer rest.ResetWriteHooks()
+
+		rest.MarshalledCtx(ctx, httptest.NewRecorder(), []int{1}, http.StatusOK)
+
+		assert.Len(t, events, 1)
+		assert.True(t, errors.Is(events[0].Err, context.Canceled))
+	})
+
+	t.Run("should send when context is alive", func(t *testing.T) {
+
+		recorder := httptest.NewRecorder()
+
+		_, err := rest.MarshalledCtx(context.Background(), recorder, []int{1}, http.StatusOK)
+
+		assert.NoError(t, err)
+		assert.Equal(t, `[1]`, recorder.Body.String())
+	})
+}
+
+func TestDeadlineExceeded(t *testing.T) {
+
+	testCases := []struct {
+		description string
+		code        int
+		expected    int
+	}{
+		{"should respond gateway timeout instead of internal server error", http.StatusInternalServerError, http.StatusGatewayTimeout},
+		{"should respond gateway timeout without code", 0, http.StatusGatewayTimeout},
+		{"should keep the code given", http.StatusServiceUnavailable, http.StatusServiceUnavailable},
+	}
+
+	for _, tc := range testCases {
+
+		t.Run(tc.description, func(t *testing.T) {
+
+			recorder := httptest.NewRecorder()
+
+			rest.Error(recorder, fmt.Errorf("couldn't query products: %w", context.DeadlineExceeded), tc.code)
+
+			assert.Equal(t, tc.expected, recorder.Code)
+		})
+	}
+
+	t.Run("should respond gateway timeout to unwrapped deadline", func(t *testing.T) {
+
+		for _, code := range []int{0, http.StatusInternalServerError} {
+
+			recorder := httptest.NewRecorder()
+
+			rest.Error(recorder, context.DeadlineExceeded, code)
+
+			assert.Equal(t, http.StatusGatewayTimeout, recorder.Code)
+			assert.Equal(t, `{"message":"context deadline exceeded"}`, recorder.Body.String())
+		}
+	})
+}
@@ -0,0 +1,146 @@
+// Package resttest help to test handlers which respond json:
+//
+//	result := resttest.Do(handler, resttest.NewRequest(http.MethodPost, "/products", product))
+//	result.AssertStatus(t, http.StatusCreated)
+//	result.AssertJSONEq(t, `{"name":"tv"}`)
+package resttest
+
+import (
+	"bytes"
+	"encoding/json"
+	"github.com/stretchr/testify/assert"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// UpdateEnv is the environment variable which make AssertGolden write the golden files, like RESTTEST_UPDATE=1 go test
+const UpdateEnv = "RESTTEST_UPDATE"
+
+// Result is the response of a handler
+type Result struct {
+	*http.Response
+	// Body is the body of response, already read
+	Body []byte
+}
+
+// Do serve the request with handler, recording the response
+func Do(handler http.Handler, req *http.Request) *Result {
+
+	recorder := httptest.NewRecorder()
+
+	handler.ServeHTTP(recorder, req)
+
+	resp := recorder.Result()
+
+	body, _ := ioutil.ReadAll(resp.Body)
+
+	resp.Body.Close()
+
+	resp.Body = ioutil.NopCloser(bytes.NewReader(body))
+
+	return &Result{Response: resp, Body: body}
+}
+
+// NewRequest create a request with body marshalled as json, a string or []byte body is sent as is
+func NewRequest(method, target string, body interface{}) *http.Request {
+
+	var reader io.Reader
+
+	switch value := body.(type) {
+	case nil:
+	case string:
+		reader = strings.NewReader(value)
+	case []byte:
+		reader = bytes.NewReader(value)
+	default:
+		encoded, err := json.Marshal(value)
+		if err != nil {
+			panic("resttest: couldn't marshal body: " + err.Error())
+		}
+		reader = bytes.NewReader(encoded)
+	}
+
+	req := httptest.NewRequest(method, target, reader)
+
+	if reader != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+
+	return req
+}
+
+// AssertStatus assert the status of response
+func (r *Result) AssertStatus(t testing.TB, expected int) bool {
+	t.Helper()
+	return assert.Equal(t, expected, r.StatusCode, "status of response, body: %s", r.Body)
+}
+
+// AssertHeader assert a header of response
+func (r *Result) AssertHeader(t testing.TB, key, expected string) bool {
+	t.Helper()
+	return assert.Equal(t, expected, r.Header.Get(key), "header %s of response", key)
+}
+
+// AssertJSONEq assert the body is a json equal to expected, ignoring the order of keys and spaces
+func (r *Result) AssertJSONEq(t testing.TB, expected string) bool {
+	t.Helper()
+	return assert.JSONEq(t, expected, string(r.Body))
+}
+
+// AssertJSON assert the body is a valid json with a json Content-Type
+func (r *Result) AssertJSON(t testing.TB) bool {
+	t.Helper()
+	return assert.Contains(t, r.Header.Get("Content-Type"), "json") && assert.True(t, json.Valid(r.Body), "body of response is not a json: %s", r.Body)
+}
+
+// Decode unmarshal the body of response on v, failing the test when cannot
+func (r *Result) Decode(t testing.TB, v interface{}) bool {
+	t.Helper()
+	return assert.NoError(t, json.Unmarshal(r.Body, v), "body of response: %s", r.Body)
+}
+
+// AssertGolden compare the body with the golden file on path, json bodies are compared ignoring
+// the order of keys. The file is written when RESTTEST_UPDATE is set.
+func (r *Result) AssertGolden(t testing.TB, path string) bool {
+
+	t.Helper()
+
+	body := r.Body
+
+	indented := &bytes.Buffer{}
+
+	if json.Indent(indented, body, "", "  ") == nil {
+		body = append(indented.Bytes(), '\n')
+	}
+
+	if os.Getenv(UpdateEnv) != "" {
+
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatalf("couldn't create directory of golden file: %v", err)
+		}
+
+		if err := ioutil.WriteFile(path, body, 0644); err != nil {
+			t.Fatalf("couldn't write golden file: %v", err)
+		}
+
+		return true
+	}
+
+	golden, err := ioutil.ReadFile(path)
+
+	if err != nil {
+		t.Fatalf("couldn't read golden file, run with %s=1 to create it: %v", UpdateEnv, err)
+	}
+
+	if json.Valid(golden) && json.Valid(r.Body) {
+		return assert.JSONEq(t, string(golden), string(r.Body), "body differ from golden file %s", path)
+	}
+
+	return assert.Equal(t, string(golden), string(body), "body differ from golden file %s", path)
+}
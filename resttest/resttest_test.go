@@ -0,0 +1,82 @@
+package resttest_test
+
+import (
+	"github.com/edermanoel94/rest-go"
+	"github.com/edermanoel94/rest-go/resttest"
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"testing"
+)
+
+type product struct {
+	Name  string  `json:"name"`
+	Price float64 `json:"price"`
+}
+
+var createProduct = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+
+	var p product
+
+	if err := rest.Bind(r, &p); err != nil {
+		rest.Error(w, err, 0)
+		return
+	}
+
+	rest.Created(w, &p, "/products/1")
+})
+
+// failRecorder record a failure instead of failing the test
+type failRecorder struct {
+	testing.TB
+	failed bool
+}
+
+func (f *failRecorder) Errorf(string, ...interface{}) {
+	f.failed = true
+}
+
+func (f *failRecorder) Helper() {}
+
+func TestDo(t *testing.T) {
+
+	t.Run("should record the response of handler", func(t *testing.T) {
+
+		result := resttest.Do(createProduct, resttest.NewRequest(http.MethodPost, "/products", &product{Name: "tv", Price: 10}))
+
+		result.AssertStatus(t, http.StatusCreated)
+		result.AssertHeader(t, "Location", "/products/1")
+		result.AssertJSON(t)
+		result.AssertJSONEq(t, `{"price": 10, "name": "tv"}`)
+
+		var decoded product
+
+		result.Decode(t, &decoded)
+
+		assert.Equal(t, product{Name: "tv", Price: 10}, decoded)
+	})
+
+	t.Run("should send a string body as is", func(t *testing.T) {
+
+		result := resttest.Do(createProduct, resttest.NewRequest(http.MethodPost, "/products", `{"name":`))
+
+		result.AssertStatus(t, http.StatusBadRequest)
+		result.AssertJSONEq(t, `{"message":"request body have a malformed json"}`)
+	})
+
+	t.Run("should compare body with golden file", func(t *testing.T) {
+
+		result := resttest.Do(createProduct, resttest.NewRequest(http.MethodPost, "/products", &product{Name: "tv", Price: 10}))
+
+		result.AssertGolden(t, "testdata/created.golden")
+	})
+
+	t.Run("should report a status which differ", func(t *testing.T) {
+
+		result := resttest.Do(createProduct, resttest.NewRequest(http.MethodPost, "/products", nil))
+
+		mock := &failRecorder{TB: t}
+
+		assert.False(t, result.AssertStatus(mock, http.StatusCreated))
+		assert.True(t, mock.failed)
+	})
+}
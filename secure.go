@@ -0,0 +1,75 @@
+package rest
+
+import (
+	"fmt"
+	"net/http"
+	"time"
+)
+
+// SecureOptions configure the headers sent by SecureHeaders, a empty value is not sent
+type SecureOptions struct {
+	ContentTypeOptions    string
+	FrameOptions          string
+	ReferrerPolicy        string
+	ContentSecurityPolicy string
+	// HSTSMaxAge is the max-age of Strict-Transport-Security, which is sent only on https
+	HSTSMaxAge            time.Duration
+	HSTSIncludeSubdomains bool
+	HSTSPreload           bool
+}
+
+// DefaultSecureOptions are the headers expected of a json API
+var DefaultSecureOptions = SecureOptions{
+	ContentTypeOptions:    "nosniff",
+	FrameOptions:          "DENY",
+	ReferrerPolicy:        "no-referrer",
+	ContentSecurityPolicy: "default-src 'none'; frame-ancestors 'none'",
+	HSTSMaxAge:            365 * 24 * time.Hour,
+	HSTSIncludeSubdomains: true,
+}
+
+// SecureHeaders is a middleware which set security headers on every response, a handler can still override
+// them. Strict-Transport-Security is sent only when the request is https, directly or by X-Forwarded-Proto.
+func SecureHeaders(opts SecureOptions) func(http.Handler) http.Handler {
+
+	hsts := ""
+
+	if opts.HSTSMaxAge > 0 {
+
+		hsts = fmt.Sprintf("max-age=%d", int64(opts.HSTSMaxAge/time.Second))
+
+		if opts.HSTSIncludeSubdomains {
+			hsts += "; includeSubDomains"
+		}
+
+		if opts.HSTSPreload {
+			hsts += "; preload"
+		}
+	}
+
+	headers := map[string]string{
+		"X-Content-Type-Options":  opts.ContentTypeOptions,
+		"X-Frame-Options":         opts.FrameOptions,
+		"Referrer-Policy":         opts.ReferrerPolicy,
+		"Content-Security-Policy": opts.ContentSecurityPolicy,
+	}
+
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+
+			header := w.Header()
+
+			for key, value := range headers {
+				if value != "" {
+					header.Set(key, value)
+				}
+			}
+
+			if hsts != "" && (r.TLS != nil || r.Header.Get("X-Forwarded-Proto") == "https") {
+				header.Set("Strict-Transport-Security", hsts)
+			}
+
+			next.ServeHTTP(w, r)
+		})
+	}
+}
@@ -0,0 +1,72 @@
+package rest_test
+
+import (
+	"crypto/tls"
+	"github.com/edermanoel94/rest-go"
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestSecureHeaders(t *testing.T) {
+
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		rest.Marshalled(w, []int{1}, http.StatusOK)
+	})
+
+	t.Run("should send default headers", func(t *testing.T) {
+
+		recorder := httptest.NewRecorder()
+
+		rest.SecureHeaders(rest.DefaultSecureOptions)(handler).ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/", nil))
+
+		assert.Equal(t, "nosniff", recorder.Header().Get("X-Content-Type-Options"))
+		assert.Equal(t, "DENY", recorder.Header().Get("X-Frame-Options"))
+		assert.Equal(t, "no-referrer", recorder.Header().Get("Referrer-Policy"))
+		assert.Equal(t, "default-src 'none'; frame-ancestors 'none'", recorder.Header().Get("Content-Security-Policy"))
+		assert.Empty(t, recorder.Header().Get("Strict-Transport-Security"))
+	})
+
+	t.Run("should send hsts on https", func(t *testing.T) {
+
+		request := httptest.NewRequest(http.MethodGet, "/", nil)
+		request.TLS = &tls.ConnectionState{}
+
+		recorder := httptest.NewRecorder()
+
+		rest.SecureHeaders(rest.DefaultSecureOptions)(handler).ServeHTTP(recorder, request)
+
+		assert.Equal(t, "max-age=31536000; includeSubDomains", recorder.Header().Get("Strict-Transport-Security"))
+	})
+
+	t.Run("should send hsts behind a proxy with https", func(t *testing.T) {
+
+		request := httptest.NewRequest(http.MethodGet, "/", nil)
+		request.Header.Set("X-Forwarded-Proto", "https")
+
+		recorder := httptest.NewRecorder()
+
+		opts := rest.SecureOptions{HSTSMaxAge: time.Hour, HSTSPreload: true}
+
+		rest.SecureHeaders(opts)(handler).ServeHTTP(recorder, request)
+
+		assert.Equal(t, "max-age=3600; preload", recorder.Header().Get("Strict-Transport-Security"))
+		assert.Empty(t, recorder.Header().Get("X-Frame-Options"))
+	})
+
+	t.Run("should let handler override a header", func(t *testing.T) {
+
+		override := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("X-Frame-Options", "SAMEORIGIN")
+			rest.Marshalled(w, []int{1}, http.StatusOK)
+		})
+
+		recorder := httptest.NewRecorder()
+
+		rest.SecureHeaders(rest.DefaultSecureOptions)(override).ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/", nil))
+
+		assert.Equal(t, "SAMEORIGIN", recorder.Header().Get("X-Frame-Options"))
+	})
+}
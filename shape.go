@@ -0,0 +1,463 @@
+package rest
+
+import (
+	"bytes"
+	"context"
+	"encoding"
+	"encoding/json"
+	"net/http"
+	"reflect"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+var (
+	jsonMarshalerType = reflect.TypeOf((*json.Marshaler)(nil)).Elem()
+	textMarshalerType = reflect.TypeOf((*encoding.TextMarshaler)(nil)).Elem()
+)
+
+// shaper walk a value before marshalling, deciding which struct fields are rendered.
+// Values which implements json.Marshaler or encoding.TextMarshaler are not walked.
+type shaper struct {
+	// keep report if a struct field must be rendered
+	keep []func(field reflect.StructField) bool
+	// replace can change the value rendered of a struct field, reporting true when done
+	replace []func(field reflect.StructField, value reflect.Value) (interface{}, bool)
+	// emptyCollections shape nil slices as [] and nil maps as {}, instead of null
+	emptyCollections bool
+}
+
+// object is a json object which keep the order of struct fields
+type object []objectField
+
+type objectField struct {
+	name  string
+	value interface{}
+}
+
+func (o object) MarshalJSON() ([]byte, error) {
+
+	buf := bytes.NewBufferString("{")
+
+	for i, field := range o {
+
+		if i > 0 {
+			buf.WriteByte(',')
+		}
+
+		name, err := json.Marshal(field.name)
+
+		if err != nil {
+			return nil, err
+		}
+
+		value, err := marshalJson(field.value)
+
+		if err != nil {
+			return nil, err
+		}
+
+		buf.Write(name)
+		buf.WriteByte(':')
+		buf.Write(value)
+	}
+
+	buf.WriteByte('}')
+
+	return buf.Bytes(), nil
+}
+
+// marshalledShaped shape v before marshall and respond json
+func marshalledShaped(ctx context.Context, w http.ResponseWriter, s *shaper, v interface{}, code int) (int, error) {
+	return newCall(ctx).marshalled(w, s.shape(reflect.ValueOf(v)), code)
+}
+
+// shape return a representation of v ready to marshal as json. A value which reference itself
+// is shaped as a value which fail to marshal, like encoding/json.
+func (s *shaper) shape(v reflect.Value) interface{} {
+	return s.walk(v, map[visit]bool{})
+}
+
+// visit is a pointer been walked, with the length of slices like encoding/json
+type visit struct {
+	ptr uintptr
+	typ reflect.Type
+	len int
+}
+
+// cycle is shaped instead of a value which reference itself
+type cycle struct {
+	value reflect.Value
+}
+
+func (c cycle) MarshalJSON() ([]byte, error) {
+	return nil, &json.UnsupportedValueError{Value: c.value, Str: "encountered a cycle via " + c.value.Type().String()}
+}
+
+func (s *shaper) walk(v reflect.Value, visited map[visit]bool) interface{} {
+
+	if !v.IsValid() {
+		return nil
+	}
+
+	if v.Type().Implements(jsonMarshalerType) || v.Type().Implements(textMarshalerType) {
+		return v.Interface()
+	}
+
+	if v.CanAddr() && (reflect.PtrTo(v.Type()).Implements(jsonMarshalerType) ||
+		reflect.PtrTo(v.Type()).Implements(textMarshalerType)) {
+		return v.Addr().Interface()
+	}
+
+	switch v.Kind() {
+	case reflect.Ptr, reflect.Map, reflect.Slice:
+		if !v.IsNil() && v.Type().Elem().Kind() != reflect.Uint8 {
+			key := visit{ptr: v.Pointer(), typ: v.Type()}
+			if v.Kind() == reflect.Slice {
+				key.len = v.Len()
+			}
+			if visited[key] {
+				return cycle{value: v}
+			}
+			visited[key] = true
+			defer delete(visited, key)
+		}
+	}
+
+	switch v.Kind() {
+	case reflect.Ptr, reflect.Interface:
+		if v.IsNil() {
+			return nil
+		}
+		return s.walk(v.Elem(), visited)
+	case reflect.Struct:
+		return s.shapeStruct(v, visited)
+	case reflect.Slice:
+		if v.Type().Elem().Kind() == reflect.Uint8 {
+			return v.Interface()
+		}
+		if v.IsNil() {
+			if s.emptyCollections {
+				return []interface{}{}
+			}
+			return nil
+		}
+		fallthrough
+	case reflect.Array:
+		items := make([]interface{}, v.Len())
+		for i := 0; i < v.Len(); i++ {
+			items[i] = s.walk(v.Index(i), visited)
+		}
+		return items
+	case reflect.Map:
+		if !isMapKey(v.Type().Key()) {
+			return v.Interface()
+		}
+		if v.IsNil() {
+			if s.emptyCollections {
+				return map[string]interface{}{}
+			}
+			return nil
+		}
+		items := make(map[string]interface{}, v.Len())
+		iter := v.MapRange()
+		for iter.Next() {
+			key, ok := mapKey(iter.Key())
+			if !ok {
+				return v.Interface()
+			}
+			items[key] = s.walk(iter.Value(), visited)
+		}
+		return items
+	default:
+		return v.Interface()
+	}
+}
+
+// isMapKey report if encoding/json can encode a map with keys of t
+func isMapKey(t reflect.Type) bool {
+	switch t.Kind() {
+	case reflect.String,
+		reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return true
+	}
+	return t.Implements(textMarshalerType)
+}
+
+// mapKey return the name of a map key like encoding/json, false when it can't be encoded
+func mapKey(key reflect.Value) (string, bool) {
+
+	if key.Kind() == reflect.String {
+		return key.String(), true
+	}
+
+	if marshaler, ok := key.Interface().(encoding.TextMarshaler); ok {
+		if key.Kind() == reflect.Ptr && key.IsNil() {
+			return "", true
+		}
+		text, err := marshaler.MarshalText()
+		return string(text), err == nil
+	}
+
+	switch key.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return strconv.FormatInt(key.Int(), 10), true
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return strconv.FormatUint(key.Uint(), 10), true
+	}
+
+	return "", false
+}
+
+func (s *shaper) shapeStruct(v reflect.Value, visited map[visit]bool) object {
+
+	fields := structFields(v.Type())
+
+	result := make(object, 0, len(fields))
+
+	for _, field := range fields {
+
+		fieldValue, ok := fieldByIndex(v, field.index)
+
+		if !ok || !s.keepField(field.field) {
+			continue
+		}
+
+		if field.opts.contains("omitempty") && isEmptyValue(fieldValue) {
+			continue
+		}
+
+		value, replaced := s.replaceField(field.field, fieldValue)
+
+		if !replaced {
+			value = s.shapeField(fieldValue, field.opts, visited)
+		}
+
+		result = append(result, objectField{name: field.name, value: value})
+	}
+
+	return result
+}
+
+// structField is a field rendered on json, like encoding/json fields of embedded structs are promoted
+type structField struct {
+	name   string
+	index  []int
+	tagged bool
+	opts   tagOptions
+	field  reflect.StructField
+}
+
+var structFieldsCache sync.Map
+
+// structFields return the json fields of a struct type, following the rules of encoding/json
+func structFields(t reflect.Type) []structField {
+
+	if cached, ok := structFieldsCache.Load(t); ok {
+		return cached.([]structField)
+	}
+
+	all := make([]structField, 0)
+
+	collectFields(t, nil, &all, map[reflect.Type]bool{})
+
+	// the shallowest field win, on same depth just a single tagged one can win, otherwise
+	// the name is ambiguous and dropped, like encoding/json
+	byName := make(map[string][]int)
+
+	for i, field := range all {
+		byName[field.name] = append(byName[field.name], i)
+	}
+
+	dominant := make(map[int]bool)
+
+	for _, indexes := range byName {
+		if i, ok := dominantField(all, indexes); ok {
+			dominant[i] = true
+		}
+	}
+
+	fields := make([]structField, 0, len(all))
+
+	for i, field := range all {
+		if dominant[i] {
+			fields = append(fields, field)
+		}
+	}
+
+	structFieldsCache.Store(t, fields)
+
+	return fields
+}
+
+// dominantField return the field which win between fields of same name, false when they are ambiguous
+func dominantField(all []structField, indexes []int) (int, bool) {
+
+	depth := len(all[indexes[0]].index)
+
+	for _, i := range indexes {
+		if len(all[i].index) < depth {
+			depth = len(all[i].index)
+		}
+	}
+
+	shallowest, tagged := make([]int, 0, len(indexes)), make([]int, 0, len(indexes))
+
+	for _, i := range indexes {
+		if len(all[i].index) == depth {
+			shallowest = append(shallowest, i)
+			if all[i].tagged {
+				tagged = append(tagged, i)
+			}
+		}
+	}
+
+	if len(shallowest) == 1 {
+		return shallowest[0], true
+	}
+
+	if len(tagged) == 1 {
+		return tagged[0], true
+	}
+
+	return 0, false
+}
+
+func collectFields(t reflect.Type, index []int, all *[]structField, visited map[reflect.Type]bool) {
+
+	if visited[t] {
+		return
+	}
+
+	visited[t] = true
+
+	defer delete(visited, t)
+
+	for i := 0; i < t.NumField(); i++ {
+
+		field := t.Field(i)
+		tag := field.Tag.Get("json")
+
+		if tag == "-" {
+			continue
+		}
+
+		name, opts := parseTag(tag)
+
+		fieldIndex := make([]int, len(index)+1)
+		copy(fieldIndex, index)
+		fieldIndex[len(index)] = i
+
+		if field.Anonymous && name == "" {
+			fieldType := field.Type
+			if fieldType.Kind() == reflect.Ptr {
+				fieldType = fieldType.Elem()
+			}
+			if fieldType.Kind() == reflect.Struct {
+				collectFields(fieldType, fieldIndex, all, visited)
+				continue
+			}
+		}
+
+		if field.PkgPath != "" {
+			continue
+		}
+
+		tagged := name != ""
+
+		if !tagged {
+			name = field.Name
+		}
+
+		*all = append(*all, structField{name: name, index: fieldIndex, tagged: tagged, opts: opts, field: field})
+	}
+}
+
+// fieldByIndex is like reflect.Value.FieldByIndex, but report false when pass through a nil pointer
+func fieldByIndex(v reflect.Value, index []int) (reflect.Value, bool) {
+	for i, x := range index {
+		if i > 0 && v.Kind() == reflect.Ptr {
+			if v.IsNil() {
+				return reflect.Value{}, false
+			}
+			v = v.Elem()
+		}
+		v = v.Field(x)
+	}
+	return v, true
+}
+
+func (s *shaper) keepField(field reflect.StructField) bool {
+	for _, keep := range s.keep {
+		if !keep(field) {
+			return false
+		}
+	}
+	return true
+}
+
+func (s *shaper) shapeField(v reflect.Value, opts tagOptions, visited map[visit]bool) interface{} {
+	if opts.contains("string") && isQuotable(v) {
+		raw, _ := marshalJson(v.Interface())
+		return string(raw)
+	}
+	return s.walk(v, visited)
+}
+
+func (s *shaper) replaceField(field reflect.StructField, value reflect.Value) (interface{}, bool) {
+	for _, replace := range s.replace {
+		if replaced, ok := replace(field, value); ok {
+			return replaced, true
+		}
+	}
+	return nil, false
+}
+
+type tagOptions string
+
+func parseTag(tag string) (string, tagOptions) {
+	if i := strings.Index(tag, ","); i != -1 {
+		return tag[:i], tagOptions(tag[i+1:])
+	}
+	return tag, ""
+}
+
+func (o tagOptions) contains(name string) bool {
+	for _, opt := range strings.Split(string(o), ",") {
+		if opt == name {
+			return true
+		}
+	}
+	return false
+}
+
+func isEmptyValue(v reflect.Value) bool {
+	switch v.Kind() {
+	case reflect.Array, reflect.Map, reflect.Slice, reflect.String:
+		return v.Len() == 0
+	case reflect.Bool:
+		return !v.Bool()
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return v.Int() == 0
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return v.Uint() == 0
+	case reflect.Float32, reflect.Float64:
+		return v.Float() == 0
+	case reflect.Interface, reflect.Ptr:
+		return v.IsNil()
+	}
+	return false
+}
+
+func isQuotable(v reflect.Value) bool {
+	switch v.Kind() {
+	case reflect.Bool, reflect.String,
+		reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr,
+		reflect.Float32, reflect.Float64:
+		return true
+	}
+	return false
+}
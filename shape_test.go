@@ -0,0 +1,111 @@
+package rest
+
+import (
+	"encoding/json"
+	"github.com/stretchr/testify/assert"
+	"reflect"
+	"testing"
+	"time"
+)
+
+type shapeInner struct {
+	ID   int    `json:"id"`
+	Name string `json:"name"`
+}
+
+type shapeOuter struct {
+	*shapeInner
+	Name    string            `json:"name"`
+	Count   int               `json:"count,string"`
+	Skip    string            `json:"-"`
+	Empty   string            `json:",omitempty"`
+	Items   []shapeInner      `json:"items"`
+	Labels  map[string]string `json:"labels"`
+	Raw     []byte            `json:"raw"`
+	When    time.Time         `json:"when"`
+	Any     interface{}       `json:"any"`
+	private string
+}
+
+type shapeEmbedA struct{ ID int }
+
+type shapeEmbedB struct{ ID int }
+
+type shapeEmbedC struct{ ID int }
+
+type shapeEmbedTagged struct {
+	ID int `json:"ID"`
+}
+
+// shapeCollide embed three structs with a field of same name on same depth
+type shapeCollide struct {
+	shapeEmbedA
+	shapeEmbedB
+	shapeEmbedC
+	Name string
+}
+
+type shapeCollideTagged struct {
+	shapeEmbedA
+	shapeEmbedTagged
+	shapeEmbedC
+	Name string
+}
+
+type shapeKey struct{ name string }
+
+func (k shapeKey) MarshalText() ([]byte, error) {
+	return []byte("key-" + k.name), nil
+}
+
+func TestShapeMatchEncodingJson(t *testing.T) {
+
+	testCases := []struct {
+		description string
+		value       interface{}
+	}{
+		{"should render struct with promoted fields", shapeOuter{
+			shapeInner: &shapeInner{ID: 1, Name: "inner"},
+			Name:       "outer",
+			Count:      3,
+			Items:      []shapeInner{{ID: 2}},
+			Labels:     map[string]string{"b": "2", "a": "1"},
+			Raw:        []byte("cale"),
+			When:       time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC),
+			Any:        shapeInner{ID: 3},
+			private:    "x",
+		}},
+		{"should render nil embedded pointer", shapeOuter{Name: "outer"}},
+		{"should render slice of pointers", []*shapeInner{{ID: 1}, nil}},
+		{"should drop field ambiguous between three embedded structs", shapeCollide{
+			shapeEmbedA: shapeEmbedA{ID: 1}, shapeEmbedB: shapeEmbedB{ID: 2}, shapeEmbedC: shapeEmbedC{ID: 3}, Name: "tv"}},
+		{"should keep the tagged field between embedded structs", shapeCollideTagged{
+			shapeEmbedA: shapeEmbedA{ID: 1}, shapeEmbedTagged: shapeEmbedTagged{ID: 2}, shapeEmbedC: shapeEmbedC{ID: 3}, Name: "tv"}},
+		{"should render map with int keys", map[int]shapeInner{2: {ID: 2}, 1: {ID: 1}}},
+		{"should render map with text marshaler keys", map[shapeKey]shapeInner{{"b"}: {ID: 2}, {"a"}: {ID: 1}}},
+		{"should render scalar", 42},
+		{"should render nil", nil},
+	}
+
+	for _, tc := range testCases {
+
+		t.Run(tc.description, func(t *testing.T) {
+
+			expected, err := json.Marshal(tc.value)
+
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			s := &shaper{}
+
+			actual, err := json.Marshal(s.shape(reflect.ValueOf(tc.value)))
+
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			assert.Equal(t, string(expected), string(actual))
+		})
+	}
+}
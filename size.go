@@ -0,0 +1,309 @@
+package rest
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"reflect"
+	"sort"
+	"strconv"
+	"sync"
+)
+
+// SizePolicy decide what happens to a response larger than MaxBodySize
+type SizePolicy int
+
+const (
+	// FailOversized respond 500 with ErrResponseTooLarge
+	FailOversized SizePolicy = iota
+	// TruncateOversized send the items of a slice which fit, inside of a envelope like
+	// {"data":[...],"truncated":true,"total":5000,"warning":"..."}
+	TruncateOversized
+	// StreamOversized write the items of a slice as they are encoded, without limit and
+	// without keeping the body on memory
+	StreamOversized
+)
+
+var (
+	ErrResponseTooLarge = errors.New("response is too large")
+)
+
+// truncatedEmpty is the size of a truncated page without items and numbers
+const truncatedEmpty = len(`{"data":[],"truncated":true,"total":,"warning":"response truncated to  of  items"}`)
+
+var (
+	maxBodySizeMu sync.RWMutex
+	maxBodySize   int
+	sizePolicy    SizePolicy
+)
+
+// SetMaxBodySize limit the size of every body sent by the package, with the policy for
+// larger bodies. A n of 0 remove the limit, which is the default. MaxBodySize and WithSizePolicy
+// change them for a response.
+func SetMaxBodySize(n int, policy SizePolicy) {
+	maxBodySizeMu.Lock()
+	defer maxBodySizeMu.Unlock()
+	maxBodySize = n
+	sizePolicy = policy
+}
+
+// MaxBodySize limit the size of body on bytes, larger bodies follow the size policy, see WithSizePolicy.
+// Just a slice can be truncated or streamed, other values larger than n always respond 500.
+func MaxBodySize(n int) Option {
+	return func(o *options) {
+		o.maxBodySize = &n
+	}
+}
+
+// WithSizePolicy change what happens to a body larger than MaxBodySize
+func WithSizePolicy(policy SizePolicy) Option {
+	return func(o *options) {
+		o.sizePolicy = &policy
+	}
+}
+
+// sizeLimit return the max body size and its policy, 0 when there is no limit
+func (o *options) sizeLimit() (int, SizePolicy) {
+
+	maxBodySizeMu.RLock()
+	n, policy := maxBodySize, sizePolicy
+	maxBodySizeMu.RUnlock()
+
+	if o.maxBodySize != nil {
+		n = *o.maxBodySize
+	}
+
+	if o.sizePolicy != nil {
+		policy = *o.sizePolicy
+	}
+
+	return n, policy
+}
+
+// encodeItem encode a item of a slice with enc, which must produce valid json to be sent inside of the array
+func encodeItem(enc Encoder, v interface{}) ([]byte, error) {
+
+	item, err := enc.Encode(v)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if !valid(applicationJson, item) {
+		return nil, ErrNotValidJson
+	}
+
+	return item, nil
+}
+
+// sliceItems return the items of v when it is a slice or array encoded as a json array
+func sliceItems(v interface{}) (reflect.Value, bool) {
+
+	items := reflect.ValueOf(v)
+
+	switch items.Kind() {
+	case reflect.Slice:
+		if items.IsNil() || items.Type().Elem().Kind() == reflect.Uint8 {
+			return items, false
+		}
+	case reflect.Array:
+	default:
+		return items, false
+	}
+
+	if items.Type().Implements(jsonMarshalerType) || items.Type().Implements(textMarshalerType) {
+		return items, false
+	}
+
+	return items, true
+}
+
+// tooLarge return the error of a body larger than limit
+func tooLarge(limit int) error {
+	return fmt.Errorf("%w, larger than %d bytes", ErrResponseTooLarge, limit)
+}
+
+// truncatedPage is the envelope of a slice truncated by TruncateOversized
+type truncatedPage struct {
+	Data      json.RawMessage `json:"data"`
+	Truncated bool            `json:"truncated"`
+	Total     int             `json:"total"`
+	Warning   string          `json:"warning"`
+}
+
+// marshalledSlice encode the items of a slice one by one, so a slice larger than limit is
+// found without encoding every item
+func (c *call) marshalledSlice(w http.ResponseWriter, enc Encoder, items reflect.Value, code int, limit int, policy SizePolicy) (int, error) {
+
+	buf := getBuffer()
+	defer putBuffer(buf)
+
+	// the items must fit with the envelope of truncated page
+	available := limit
+	if policy == TruncateOversized {
+		available -= truncatedEmpty + 3*len(strconv.Itoa(items.Len()))
+	}
+
+	// ends is the size of buf after each item, so a truncated page can drop items
+	ends := make([]int, 0, items.Len())
+
+	buf.WriteByte('[')
+
+	for i := 0; i < items.Len(); i++ {
+
+		item, err := encodeItem(enc, items.Index(i).Interface())
+
+		if err != nil {
+			return c.fail(w, err, http.StatusInternalServerError)
+		}
+
+		if buf.Len()+len(item)+2 > available {
+			switch policy {
+			case TruncateOversized:
+				return c.truncated(w, buf, ends, items.Len(), code, limit)
+			case StreamOversized:
+				return c.stream(w, enc, buf, items, i, item, code)
+			default:
+				return c.fail(w, tooLarge(limit), http.StatusInternalServerError)
+			}
+		}
+
+		if i > 0 {
+			buf.WriteByte(',')
+		}
+
+		buf.Write(item)
+
+		ends = append(ends, buf.Len())
+	}
+
+	buf.WriteByte(']')
+
+	body := c.opts.indentJson(buf.Bytes())
+
+	// the items were measured without indent
+	if len(body) > limit {
+		switch policy {
+		case TruncateOversized:
+			return c.truncated(w, buf, ends, items.Len(), code, limit)
+		case StreamOversized:
+			buf.Truncate(buf.Len() - 1)
+			return c.stream(w, enc, buf, items, items.Len(), nil, code)
+		}
+	}
+
+	return c.write(w, applicationJson, body, code)
+}
+
+// truncated send the most items encoded on buf which fit inside of a truncated page of limit
+// bytes once indented, ends is the size of buf after each item
+func (c *call) truncated(w http.ResponseWriter, buf *bytes.Buffer, ends []int, total int, code int, limit int) (int, error) {
+
+	var err error
+
+	page := func(count int) []byte {
+
+		end := 1
+		if count > 0 {
+			end = ends[count-1]
+		}
+
+		data := append(append(make([]byte, 0, end+1), buf.Bytes()[:end]...), ']')
+
+		body, marshalErr := marshalJson(&truncatedPage{
+			Data:      data,
+			Truncated: true,
+			Total:     total,
+			Warning:   fmt.Sprintf("response truncated to %d of %d items", count, total),
+		})
+
+		if marshalErr != nil {
+			err = marshalErr
+		}
+
+		return c.opts.indentJson(body)
+	}
+
+	body := page(len(ends))
+
+	if len(body) > limit {
+		// the first count which don't fit, pages grow with count
+		count := sort.Search(len(ends)+1, func(count int) bool {
+			return len(page(count)) > limit
+		})
+		if count > 0 {
+			count--
+		}
+		body = page(count)
+	}
+
+	if err != nil {
+		return c.fail(w, err, http.StatusInternalServerError)
+	}
+
+	return c.write(w, applicationJson, body, code)
+}
+
+// stream write the items encoded on buf, then encode and write the items from next, flushing
+// after each one. The body is not indented, transformed or intercepted.
+func (c *call) stream(w http.ResponseWriter, enc Encoder, buf *bytes.Buffer, items reflect.Value, next int, item []byte, code int) (int, error) {
+
+	header := w.Header()
+
+	applyDefaultHeaders(header)
+
+	if id := RequestIDFromContext(c.ctx); id != "" && header.Get(RequestIDHeader) == "" {
+		header.Set(RequestIDHeader, id)
+	}
+
+	c.opts.apply(header, code)
+
+	mediaType := c.opts.mediaType(applicationJson)
+
+	header.Set(contentType, mediaType)
+	header.Del("Content-Length")
+
+	w.WriteHeader(code)
+
+	flusher, _ := w.(http.Flusher)
+
+	written := 0
+
+	send := func(b []byte) error {
+		n, err := w.Write(b)
+		written += n
+		if err == nil && flusher != nil {
+			flusher.Flush()
+		}
+		return err
+	}
+
+	err := send(buf.Bytes())
+
+	for i := next; err == nil && i < items.Len(); i++ {
+
+		if i > next {
+			if item, err = encodeItem(enc, items.Index(i).Interface()); err != nil {
+				// the status is already sent, the response is cut
+				c.record(err)
+				break
+			}
+		}
+
+		if i > 0 {
+			item = append([]byte(","), item...)
+		}
+
+		err = send(item)
+	}
+
+	if err == nil {
+		err = send([]byte("]"))
+	}
+
+	c.notify(mediaType, code, written, err)
+
+	return written, err
+}
@@ -0,0 +1,139 @@
+package rest_test
+
+import (
+	"encoding/json"
+	"github.com/edermanoel94/rest-go"
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestMaxBodySize(t *testing.T) {
+
+	items := []string{"item-1", "item-2", "item-3", "item-4", "item-5", "item-6", "item-7", "item-8", "item-9", "item-10"}
+
+	testCases := []struct {
+		description string
+		value       interface{}
+		opts        []rest.Option
+		statusCode  int
+		body        string
+	}{
+		{"should send body smaller than limit", items, []rest.Option{rest.MaxBodySize(1000)},
+			http.StatusOK, `["item-1","item-2","item-3","item-4","item-5","item-6","item-7","item-8","item-9","item-10"]`},
+		{"should fail slice larger than limit", items, []rest.Option{rest.MaxBodySize(50)},
+			http.StatusInternalServerError, `{"message":"response is too large, larger than 50 bytes"}`},
+		{"should fail value larger than limit", map[string][]string{"items": items}, []rest.Option{rest.MaxBodySize(50), rest.WithSizePolicy(rest.TruncateOversized)},
+			http.StatusInternalServerError, `{"message":"response is too large, larger than 50 bytes"}`},
+		{"should truncate slice larger than limit", items, []rest.Option{rest.MaxBodySize(130), rest.WithSizePolicy(rest.TruncateOversized)},
+			http.StatusOK, `{"data":["item-1","item-2","item-3","item-4"],"truncated":true,"total":10,"warning":"response truncated to 4 of 10 items"}`},
+		{"should stream slice larger than limit", items, []rest.Option{rest.MaxBodySize(20), rest.WithSizePolicy(rest.StreamOversized)},
+			http.StatusOK, `["item-1","item-2","item-3","item-4","item-5","item-6","item-7","item-8","item-9","item-10"]`},
+		{"should send bytes as base64", []byte(strings.Repeat("a", 30)), []rest.Option{rest.MaxBodySize(50), rest.WithSizePolicy(rest.StreamOversized)},
+			http.StatusOK, `"YWFhYWFhYWFhYWFhYWFhYWFhYWFhYWFhYWFhYWFh"`},
+	}
+
+	for _, tc := range testCases {
+
+		t.Run(tc.description, func(t *testing.T) {
+
+			recorder := httptest.NewRecorder()
+
+			rest.Marshalled(recorder, tc.value, http.StatusOK, tc.opts...)
+
+			assert.Equal(t, tc.statusCode, recorder.Code)
+			assert.Equal(t, "application/json", recorder.Header().Get("Content-Type"))
+			assert.Equal(t, tc.body, recorder.Body.String())
+		})
+	}
+
+	t.Run("should keep truncated body inside of limit", func(t *testing.T) {
+
+		for limit := 100; limit < 200; limit++ {
+
+			recorder := httptest.NewRecorder()
+
+			rest.Marshalled(recorder, items, http.StatusOK, rest.MaxBodySize(limit), rest.WithSizePolicy(rest.TruncateOversized))
+
+			assert.True(t, recorder.Body.Len() <= limit, "body of %d bytes is larger than %d", recorder.Body.Len(), limit)
+		}
+	})
+
+	t.Run("should keep indented truncated body inside of limit", func(t *testing.T) {
+
+		many := append(append(append([]string{}, items...), items...), items...)
+
+		for limit := 150; limit < 300; limit++ {
+
+			recorder := httptest.NewRecorder()
+
+			rest.Marshalled(recorder, many, http.StatusOK, rest.MaxBodySize(limit), rest.WithSizePolicy(rest.TruncateOversized), rest.Indent("  "))
+
+			assert.Equal(t, http.StatusOK, recorder.Code)
+			assert.Contains(t, recorder.Body.String(), "\n  \"truncated\": true")
+			assert.True(t, recorder.Body.Len() <= limit, "body of %d bytes is larger than %d", recorder.Body.Len(), limit)
+		}
+	})
+
+	t.Run("should return the error of a item streamed", func(t *testing.T) {
+
+		var events []rest.Event
+
+		rest.OnWrite(func(event rest.Event) {
+			events = append(events, event)
+		})
+		defer rest.ResetWriteHooks()
+
+		recorder := httptest.NewRecorder()
+
+		_, err := rest.Marshalled(recorder, []interface{}{"item-1", "item-2", brokenMarshaler{}}, http.StatusOK,
+			rest.MaxBodySize(10), rest.WithSizePolicy(rest.StreamOversized))
+
+		assert.Error(t, err)
+		assert.Equal(t, `["item-1","item-2"`, recorder.Body.String())
+		assert.Equal(t, err, events[0].WriteErr)
+	})
+
+	t.Run("should flush each item streamed", func(t *testing.T) {
+
+		recorder := &flushCounter{ResponseRecorder: httptest.NewRecorder()}
+
+		n, err := rest.Marshalled(recorder, items[:4], http.StatusCreated, rest.MaxBodySize(20), rest.WithSizePolicy(rest.StreamOversized))
+
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusCreated, recorder.Code)
+		assert.Equal(t, `["item-1","item-2","item-3","item-4"]`, recorder.Body.String())
+		assert.Equal(t, recorder.Body.Len(), n)
+		assert.Equal(t, 4, recorder.flushes)
+	})
+
+	t.Run("should truncate slice with other json encoder", func(t *testing.T) {
+
+		rest.RegisterEncoder("application/json", rest.EncoderFunc(json.Marshal))
+
+		defer rest.SetMarshaler(nil)
+
+		recorder := httptest.NewRecorder()
+
+		rest.Marshalled(recorder, items, http.StatusOK, rest.MaxBodySize(130), rest.WithSizePolicy(rest.TruncateOversized))
+
+		assert.Equal(t, http.StatusOK, recorder.Code)
+		assert.Contains(t, recorder.Body.String(), `"truncated":true`)
+	})
+
+	t.Run("should fail bytes larger than global limit", func(t *testing.T) {
+
+		rest.SetMaxBodySize(10, rest.FailOversized)
+
+		defer rest.SetMaxBodySize(0, rest.FailOversized)
+
+		recorder := httptest.NewRecorder()
+
+		rest.Response(recorder, []byte(`{"name":"a large tv"}`), http.StatusOK)
+
+		assert.Equal(t, http.StatusInternalServerError, recorder.Code)
+		assert.Equal(t, `{"message":"response is too large, larger than 10 bytes"}`, recorder.Body.String())
+	})
+}
@@ -0,0 +1,45 @@
+package rest
+
+import (
+	"context"
+	"net/http"
+)
+
+// locationBody is the json sent by Accepted and Redirect
+type locationBody struct {
+	Location string `json:"location"`
+}
+
+// Created marshall v and respond 201 with the Location of resource created
+func Created(w http.ResponseWriter, v interface{}, location string, opts ...Option) (int, error) {
+
+	return Marshalled(w, v, http.StatusCreated, append(opts, Location(location))...)
+}
+
+// Accepted respond 202 with the url where the status of processing can be seen, on
+// Location header and body, like {"location":"/jobs/42"}
+func Accepted(w http.ResponseWriter, statusURL string, opts ...Option) (int, error) {
+
+	return Marshalled(w, &locationBody{Location: statusURL}, http.StatusAccepted, append(opts, Location(statusURL))...)
+}
+
+// NoContent respond 204 without body and Content-Type
+func NoContent(w http.ResponseWriter, opts ...Option) (int, error) {
+
+	c := newCall(context.Background(), opts...)
+
+	header := w.Header()
+
+	applyDefaultHeaders(header)
+
+	c.opts.apply(header, http.StatusNoContent)
+
+	header.Del(contentType)
+	header.Del("Content-Length")
+
+	w.WriteHeader(http.StatusNoContent)
+
+	c.notify("", http.StatusNoContent, 0, nil)
+
+	return 0, nil
+}
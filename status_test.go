@@ -0,0 +1,68 @@
+package rest_test
+
+import (
+	"github.com/edermanoel94/rest-go"
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestCreated(t *testing.T) {
+
+	t.Run("should respond created with location", func(t *testing.T) {
+
+		recorder := httptest.NewRecorder()
+
+		rest.Created(recorder, map[string]int{"id": 1}, "/products/1")
+
+		assert.Equal(t, http.StatusCreated, recorder.Code)
+		assert.Equal(t, "/products/1", recorder.Header().Get("Location"))
+		assert.Equal(t, `{"id":1}`, recorder.Body.String())
+	})
+
+	t.Run("should not send location on errors", func(t *testing.T) {
+
+		recorder := httptest.NewRecorder()
+
+		rest.Created(recorder, make(chan int), "/products/1")
+
+		assert.Equal(t, http.StatusInternalServerError, recorder.Code)
+		assert.Empty(t, recorder.Header().Get("Location"))
+	})
+}
+
+func TestAccepted(t *testing.T) {
+
+	t.Run("should respond accepted with status url", func(t *testing.T) {
+
+		recorder := httptest.NewRecorder()
+
+		rest.Accepted(recorder, "/jobs/42")
+
+		assert.Equal(t, http.StatusAccepted, recorder.Code)
+		assert.Equal(t, "/jobs/42", recorder.Header().Get("Location"))
+		assert.Equal(t, `{"location":"/jobs/42"}`, recorder.Body.String())
+	})
+}
+
+func TestNoContent(t *testing.T) {
+
+	t.Run("should respond no content without body", func(t *testing.T) {
+
+		rest.SetDefaultHeaders(map[string]string{"X-Frame-Options": "DENY"})
+		defer rest.SetDefaultHeaders(nil)
+
+		recorder := httptest.NewRecorder()
+		recorder.Header().Set("Content-Type", "application/json")
+
+		rest.NoContent(recorder, rest.NoStore(), rest.MaxAge(time.Minute))
+
+		assert.Equal(t, http.StatusNoContent, recorder.Code)
+		assert.Empty(t, recorder.Header().Get("Content-Type"))
+		assert.Equal(t, "DENY", recorder.Header().Get("X-Frame-Options"))
+		assert.Equal(t, "no-store, max-age=60", recorder.Header().Get("Cache-Control"))
+		assert.Equal(t, 0, recorder.Body.Len())
+	})
+}
@@ -0,0 +1,110 @@
+package rest
+
+import (
+	"context"
+	"errors"
+	"net/http"
+)
+
+const applicationNdjson = "application/x-ndjson"
+
+var (
+	ErrStreamClosed = errors.New("stream is closed")
+)
+
+// Streamer write records as json lines (application/x-ndjson), flushing after each record
+type Streamer struct {
+	w       http.ResponseWriter
+	flusher http.Flusher
+	call    *call
+	started bool
+	closed  bool
+	status  int
+	// header is sent with the status, besides the default headers
+	header    http.Header
+	errClosed error
+	written   int
+	err       error
+}
+
+// Stream start a ndjson response, the status 200 is sent on first record
+func Stream(w http.ResponseWriter) *Streamer {
+	return newStreamer(w, ErrStreamClosed)
+}
+
+func newStreamer(w http.ResponseWriter, errClosed error) *Streamer {
+
+	flusher, _ := w.(http.Flusher)
+
+	return &Streamer{w: w, flusher: flusher, call: newCall(context.Background()), status: http.StatusOK, errClosed: errClosed}
+}
+
+// Send write v as a json line, once a write fail every next Send return the same error
+func (s *Streamer) Send(v interface{}) error {
+
+	if s.closed {
+		return s.errClosed
+	}
+
+	if s.err != nil {
+		return s.err
+	}
+
+	bytes, err := encode(applicationJson, v)
+
+	if err != nil {
+		return err
+	}
+
+	s.start()
+
+	n, err := s.w.Write(append(bytes, '\n'))
+
+	s.written += n
+
+	if err != nil {
+		s.err = err
+		return err
+	}
+
+	if s.flusher != nil {
+		s.flusher.Flush()
+	}
+
+	return nil
+}
+
+// Close finish the stream, sending the status when no record was sent
+func (s *Streamer) Close() error {
+
+	if s.closed {
+		return nil
+	}
+
+	s.start()
+	s.closed = true
+
+	s.call.notify(applicationNdjson, s.status, s.written, s.err)
+
+	return s.err
+}
+
+func (s *Streamer) start() {
+
+	if s.started {
+		return
+	}
+
+	s.started = true
+
+	header := s.w.Header()
+
+	applyDefaultHeaders(header)
+	header.Set(contentType, applicationNdjson)
+
+	for key, values := range s.header {
+		header[key] = values
+	}
+
+	s.w.WriteHeader(s.status)
+}
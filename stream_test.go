@@ -0,0 +1,63 @@
+package rest_test
+
+import (
+	"github.com/edermanoel94/rest-go"
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+type flushCounter struct {
+	*httptest.ResponseRecorder
+	flushes int
+}
+
+func (f *flushCounter) Flush() {
+	f.flushes++
+}
+
+func TestStream(t *testing.T) {
+
+	t.Run("should write records as json lines and flush each one", func(t *testing.T) {
+
+		recorder := &flushCounter{ResponseRecorder: httptest.NewRecorder()}
+
+		stream := rest.Stream(recorder)
+
+		for i := 1; i <= 3; i++ {
+			if err := stream.Send(map[string]int{"id": i}); err != nil {
+				t.Fatal(err)
+			}
+		}
+
+		assert.NoError(t, stream.Close())
+
+		assert.Equal(t, http.StatusOK, recorder.Code)
+		assert.Equal(t, "application/x-ndjson", recorder.Header().Get("Content-Type"))
+		assert.Equal(t, "{\"id\":1}\n{\"id\":2}\n{\"id\":3}\n", recorder.Body.String())
+		assert.Equal(t, 3, recorder.flushes)
+	})
+
+	t.Run("should send status on close without records", func(t *testing.T) {
+
+		recorder := httptest.NewRecorder()
+
+		stream := rest.Stream(recorder)
+
+		assert.NoError(t, stream.Close())
+		assert.Equal(t, rest.ErrStreamClosed, stream.Send(1))
+		assert.Equal(t, http.StatusOK, recorder.Code)
+		assert.Equal(t, "application/x-ndjson", recorder.Header().Get("Content-Type"))
+		assert.Empty(t, recorder.Body.String())
+	})
+
+	t.Run("should report the error of writer", func(t *testing.T) {
+
+		stream := rest.Stream(brokenWriter{httptest.NewRecorder()})
+
+		assert.EqualError(t, stream.Send(1), "broken pipe")
+		assert.EqualError(t, stream.Send(2), "broken pipe")
+		assert.EqualError(t, stream.Close(), "broken pipe")
+	})
+}
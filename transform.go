@@ -0,0 +1,51 @@
+package rest
+
+import (
+	"context"
+	"fmt"
+	"sync"
+)
+
+// Transformer change the body after marshalled and before written, like injecting a envelope
+type Transformer func(ctx context.Context, contentType string, body []byte) ([]byte, error)
+
+var (
+	transformersMu sync.RWMutex
+	transformers   []Transformer
+)
+
+// AddTransformer append a transformer to the pipeline applied by every write helper,
+// transformers run on the order they were added
+func AddTransformer(transformer Transformer) {
+	transformersMu.Lock()
+	defer transformersMu.Unlock()
+	transformers = append(transformers, transformer)
+}
+
+// SetTransformers replace the pipeline of transformers, call without arguments to remove all
+func SetTransformers(pipeline ...Transformer) {
+	transformersMu.Lock()
+	defer transformersMu.Unlock()
+	transformers = append([]Transformer(nil), pipeline...)
+}
+
+// applyTransformers run the pipeline, when a transformer fail the body is discarded
+func applyTransformers(ctx context.Context, contentType string, body []byte) ([]byte, error) {
+
+	transformersMu.RLock()
+	pipeline := transformers
+	transformersMu.RUnlock()
+
+	for _, transformer := range pipeline {
+
+		transformed, err := transformer(ctx, contentType, body)
+
+		if err != nil {
+			return nil, fmt.Errorf("couldn't transform body: %w", err)
+		}
+
+		body = transformed
+	}
+
+	return body, nil
+}
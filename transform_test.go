@@ -0,0 +1,68 @@
+package rest_test
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"github.com/edermanoel94/rest-go"
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestTransformers(t *testing.T) {
+
+	defer rest.SetTransformers()
+
+	t.Run("should apply transformers on order", func(t *testing.T) {
+
+		rest.SetTransformers(
+			func(ctx context.Context, contentType string, body []byte) ([]byte, error) {
+				assert.Equal(t, "application/json", contentType)
+				return append(append([]byte(`{"data":`), body...), '}'), nil
+			},
+		)
+
+		rest.AddTransformer(func(ctx context.Context, contentType string, body []byte) ([]byte, error) {
+			return bytes.ReplaceAll(body, []byte("<"), []byte(`\u003c`)), nil
+		})
+
+		recorder := httptest.NewRecorder()
+
+		rest.Response(recorder, []byte(`{"name":"<b>tv</b>"}`), http.StatusOK)
+
+		assert.Equal(t, http.StatusOK, recorder.Code)
+		assert.Equal(t, `{"data":{"name":"\u003cb>tv\u003c/b>"}}`, recorder.Body.String())
+	})
+
+	t.Run("should respond 500 when a transformer fail", func(t *testing.T) {
+
+		rest.SetTransformers(func(ctx context.Context, contentType string, body []byte) ([]byte, error) {
+			return nil, errors.New("watermark unavailable")
+		})
+
+		recorder := httptest.NewRecorder()
+
+		rest.Marshalled(recorder, map[string]string{"name": "tv"}, http.StatusOK)
+
+		assert.Equal(t, http.StatusInternalServerError, recorder.Code)
+		assert.Equal(t, `{"message":"couldn't transform body: watermark unavailable"}`, recorder.Body.String())
+	})
+
+	t.Run("should receive the context of request", func(t *testing.T) {
+
+		rest.SetTransformers(func(ctx context.Context, contentType string, body []byte) ([]byte, error) {
+			return []byte(`{"lang":"` + rest.LanguageFromContext(ctx) + `"}`), nil
+		})
+
+		recorder := httptest.NewRecorder()
+
+		ctx := rest.WithLanguage(context.Background(), "pt-BR")
+
+		rest.ErrorCtx(ctx, recorder, errors.New("not found"), http.StatusNotFound)
+
+		assert.Equal(t, http.StatusNotFound, recorder.Code)
+		assert.Equal(t, `{"lang":"pt-br"}`, recorder.Body.String())
+	})
+}
@@ -0,0 +1,113 @@
+package rest
+
+import (
+	"errors"
+	"math"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+)
+
+var (
+	ErrUnavailable = errors.New("service unavailable")
+	ErrMaintenance = errors.New("service under maintenance")
+)
+
+var (
+	maintenanceMu         sync.RWMutex
+	maintenanceEnabled    bool
+	maintenanceRetryAfter time.Duration
+)
+
+// UnavailableError is the error sent by Unavailable, can be returned to Error which respond 503
+// with Retry-After and retry_after field on body
+type UnavailableError struct {
+	Err        error
+	RetryAfter time.Duration
+}
+
+func (u *UnavailableError) Error() string {
+	return u.Unwrap().Error()
+}
+
+// Unwrap return the cause of error, ErrUnavailable when Err is nil
+func (u *UnavailableError) Unwrap() error {
+	if u.Err == nil {
+		return ErrUnavailable
+	}
+	return u.Err
+}
+
+// StatusCode make Error respond with 503
+func (u *UnavailableError) StatusCode() int {
+	return http.StatusServiceUnavailable
+}
+
+// Headers return Retry-After in seconds, nothing is sent when RetryAfter is not set
+func (u *UnavailableError) Headers() http.Header {
+
+	header := make(http.Header)
+
+	if seconds := u.seconds(); seconds > 0 {
+		header.Set("Retry-After", strconv.Itoa(seconds))
+	}
+
+	return header
+}
+
+// seconds return RetryAfter in seconds, rounded up
+func (u *UnavailableError) seconds() int {
+
+	if u.RetryAfter <= 0 {
+		return 0
+	}
+
+	return int(math.Ceil(u.RetryAfter.Seconds()))
+}
+
+// Unavailable respond 503 as json error, like {"message":"...","retry_after":120}, with the
+// Retry-After header. A nil err send ErrUnavailable.
+func Unavailable(w http.ResponseWriter, retryAfter time.Duration, err error, opts ...Option) (int, error) {
+
+	if err == nil {
+		err = ErrUnavailable
+	}
+
+	return Error(w, &UnavailableError{Err: err, RetryAfter: retryAfter}, http.StatusServiceUnavailable, opts...)
+}
+
+// SetMaintenance enable or disable the maintenance mode, while enabled the Maintenance
+// middleware respond 503 with ErrMaintenance and retryAfter
+func SetMaintenance(enabled bool, retryAfter time.Duration) {
+	maintenanceMu.Lock()
+	defer maintenanceMu.Unlock()
+	maintenanceEnabled = enabled
+	maintenanceRetryAfter = retryAfter
+}
+
+// InMaintenance report if the maintenance mode is enabled
+func InMaintenance() bool {
+	maintenanceMu.RLock()
+	defer maintenanceMu.RUnlock()
+	return maintenanceEnabled
+}
+
+// Maintenance is a middleware which respond 503 without calling next while the maintenance
+// mode is enabled by SetMaintenance. Handlers which must keep working, like health checks,
+// shouldn't be wrapped.
+func Maintenance(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+
+		maintenanceMu.RLock()
+		enabled, retryAfter := maintenanceEnabled, maintenanceRetryAfter
+		maintenanceMu.RUnlock()
+
+		if !enabled {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		newCall(r.Context()).fail(w, &UnavailableError{Err: ErrMaintenance, RetryAfter: retryAfter}, http.StatusServiceUnavailable)
+	})
+}
@@ -0,0 +1,107 @@
+package rest_test
+
+import (
+	"errors"
+	"fmt"
+	"github.com/edermanoel94/rest-go"
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestUnavailable(t *testing.T) {
+
+	testCases := []struct {
+		description string
+		retryAfter  time.Duration
+		err         error
+		header      string
+		body        string
+	}{
+		{"should send retry after on header and body", 2 * time.Minute, errors.New("database is down"),
+			"120", `{"message":"database is down","retry_after":120}`},
+		{"should round up retry after", 1500 * time.Millisecond, errors.New("database is down"),
+			"2", `{"message":"database is down","retry_after":2}`},
+		{"should not send retry after when is zero", 0, errors.New("database is down"),
+			"", `{"message":"database is down"}`},
+		{"should send unavailable when error is nil", time.Minute, nil,
+			"60", `{"message":"service unavailable","retry_after":60}`},
+	}
+
+	for _, tc := range testCases {
+
+		t.Run(tc.description, func(t *testing.T) {
+
+			recorder := httptest.NewRecorder()
+
+			rest.Unavailable(recorder, tc.retryAfter, tc.err)
+
+			assert.Equal(t, http.StatusServiceUnavailable, recorder.Code)
+			assert.Equal(t, tc.header, recorder.Header().Get("Retry-After"))
+			assert.Equal(t, tc.body, recorder.Body.String())
+		})
+	}
+
+	t.Run("should send unavailable error by Error", func(t *testing.T) {
+
+		recorder := httptest.NewRecorder()
+
+		err := fmt.Errorf("query: %w", &rest.UnavailableError{Err: rest.ErrUnavailable, RetryAfter: time.Second})
+
+		rest.Error(recorder, err, 0)
+
+		assert.True(t, errors.Is(err, rest.ErrUnavailable))
+		assert.Equal(t, http.StatusServiceUnavailable, recorder.Code)
+		assert.Equal(t, "1", recorder.Header().Get("Retry-After"))
+	})
+
+	t.Run("should use ErrUnavailable when error is nil", func(t *testing.T) {
+
+		recorder := httptest.NewRecorder()
+
+		err := &rest.UnavailableError{RetryAfter: time.Second}
+
+		rest.Error(recorder, err, 0)
+
+		assert.Equal(t, rest.ErrUnavailable.Error(), err.Error())
+		assert.True(t, errors.Is(err, rest.ErrUnavailable))
+		assert.Equal(t, http.StatusServiceUnavailable, recorder.Code)
+		assert.Equal(t, `{"message":"service unavailable","retry_after":1}`, recorder.Body.String())
+	})
+}
+
+func TestMaintenance(t *testing.T) {
+
+	handler := rest.Maintenance(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		rest.Marshalled(w, []int{1}, http.StatusOK)
+	}))
+
+	t.Run("should call handler when maintenance is disabled", func(t *testing.T) {
+
+		recorder := httptest.NewRecorder()
+
+		handler.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/", nil))
+
+		assert.False(t, rest.InMaintenance())
+		assert.Equal(t, http.StatusOK, recorder.Code)
+		assert.Equal(t, "[1]", recorder.Body.String())
+	})
+
+	t.Run("should respond unavailable when maintenance is enabled", func(t *testing.T) {
+
+		rest.SetMaintenance(true, 10*time.Minute)
+
+		defer rest.SetMaintenance(false, 0)
+
+		recorder := httptest.NewRecorder()
+
+		handler.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/", nil))
+
+		assert.True(t, rest.InMaintenance())
+		assert.Equal(t, http.StatusServiceUnavailable, recorder.Code)
+		assert.Equal(t, "600", recorder.Header().Get("Retry-After"))
+		assert.Equal(t, `{"message":"service under maintenance","retry_after":600}`, recorder.Body.String())
+	})
+}
@@ -0,0 +1,138 @@
+package rest
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strings"
+	"sync"
+)
+
+// FieldError is a field which failed a validation rule, like {"name":"email","rule":"required","message":"email is required"}.
+// The message is translated by the message of DefaultCatalog with key validation.<rule>, like validation.required,
+// formatted with the name and Args.
+type FieldError struct {
+	Name    string `json:"name" xml:"name"`
+	Rule    string `json:"rule" xml:"rule"`
+	Message string `json:"message" xml:"message"`
+	// Args are the params of rule, like the 3 of min=3
+	Args []interface{} `json:"-" xml:"-"`
+}
+
+// translateFields return fields with the messages on the language of context, when DefaultCatalog have them
+func translateFields(ctx context.Context, fields []FieldError) []FieldError {
+
+	translated := make([]FieldError, len(fields))
+
+	for i, field := range fields {
+
+		translated[i] = field
+
+		args := append([]interface{}{field.Name}, field.Args...)
+
+		if message, ok := DefaultCatalog.translate(LanguageFromContext(ctx), "validation."+field.Rule, args...); ok {
+			translated[i].Message = message
+		}
+	}
+
+	return translated
+}
+
+// ValidationError is returned by BindAndValidate, Error respond it as 422 with the fields on body
+type ValidationError struct {
+	Fields []FieldError
+	Err    error
+}
+
+func (v *ValidationError) Error() string {
+
+	if len(v.Fields) == 0 && v.Err != nil {
+		return v.Err.Error()
+	}
+
+	messages := make([]string, len(v.Fields))
+
+	for i, field := range v.Fields {
+		messages[i] = field.Message
+	}
+
+	return "validation failed: " + strings.Join(messages, ", ")
+}
+
+// Unwrap return the error of validator, when it didn't return fields
+func (v *ValidationError) Unwrap() error {
+	return v.Err
+}
+
+// StatusCode make Error respond 422
+func (v *ValidationError) StatusCode() int {
+	return http.StatusUnprocessableEntity
+}
+
+// StructValidator validate a struct, returning a *ValidationError with the fields which failed,
+// see the validator module to use github.com/go-playground/validator
+type StructValidator interface {
+	ValidateStruct(v interface{}) error
+}
+
+// Validatable is implemented by values which validate themselves, called after the StructValidator
+type Validatable interface {
+	Validate() error
+}
+
+var (
+	structValidatorMu sync.RWMutex
+	structValidator   StructValidator
+)
+
+// SetValidator set the validator used by BindAndValidate, a nil validator only call Validate
+func SetValidator(validator StructValidator) {
+	structValidatorMu.Lock()
+	defer structValidatorMu.Unlock()
+	structValidator = validator
+}
+
+// BindAndValidate decode the body like Bind and validate the result with the validator of
+// SetValidator and Validate, when v implements Validatable
+func BindAndValidate(r *http.Request, v interface{}, opts ...BindOption) error {
+
+	if err := Bind(r, v, opts...); err != nil {
+		return err
+	}
+
+	return Validate(v)
+}
+
+// Validate validate v with the validator of SetValidator and Validate, when v implements Validatable,
+// a error which is not a *ValidationError is wrapped on one
+func Validate(v interface{}) error {
+
+	structValidatorMu.RLock()
+	validator := structValidator
+	structValidatorMu.RUnlock()
+
+	if validator != nil {
+		if err := validator.ValidateStruct(v); err != nil {
+			return validationError(err)
+		}
+	}
+
+	if validatable, ok := v.(Validatable); ok {
+		if err := validatable.Validate(); err != nil {
+			return validationError(err)
+		}
+	}
+
+	return nil
+}
+
+func validationError(err error) error {
+
+	var validationErr *ValidationError
+
+	if errors.As(err, &validationErr) {
+		return err
+	}
+
+	return &ValidationError{Err: err}
+}
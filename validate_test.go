@@ -0,0 +1,105 @@
+package rest_test
+
+import (
+	"errors"
+	"github.com/edermanoel94/rest-go"
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+type signup struct {
+	Email string `json:"email"`
+	Age   int    `json:"age"`
+}
+
+func (s *signup) Validate() error {
+
+	var fields []rest.FieldError
+
+	if s.Email == "" {
+		fields = append(fields, rest.FieldError{Name: "email", Rule: "required", Message: "email is required"})
+	}
+
+	if s.Age < 18 {
+		fields = append(fields, rest.FieldError{Name: "age", Rule: "min", Message: "age must be at least 18"})
+	}
+
+	if len(fields) > 0 {
+		return &rest.ValidationError{Fields: fields}
+	}
+
+	return nil
+}
+
+type rejectValidator struct {
+	err error
+}
+
+func (r rejectValidator) ValidateStruct(interface{}) error {
+	return r.err
+}
+
+func newSignupRequest(body string) *http.Request {
+	request := httptest.NewRequest(http.MethodPost, "/signup", strings.NewReader(body))
+	request.Header.Set("Content-Type", "application/json")
+	return request
+}
+
+func TestBindAndValidate(t *testing.T) {
+
+	t.Run("should bind a valid body", func(t *testing.T) {
+
+		var result signup
+
+		err := rest.BindAndValidate(newSignupRequest(`{"email":"eder@mail.com","age":30}`), &result)
+
+		assert.NoError(t, err)
+		assert.Equal(t, "eder@mail.com", result.Email)
+	})
+
+	t.Run("should respond 422 with fields", func(t *testing.T) {
+
+		var result signup
+
+		err := rest.BindAndValidate(newSignupRequest(`{"age":10}`), &result)
+
+		recorder := httptest.NewRecorder()
+
+		rest.Error(recorder, err, http.StatusBadRequest)
+
+		assert.Equal(t, http.StatusUnprocessableEntity, recorder.Code)
+		assert.Equal(t, `{"message":"validation failed: email is required, age must be at least 18",`+
+			`"fields":[{"name":"email","rule":"required","message":"email is required"},`+
+			`{"name":"age","rule":"min","message":"age must be at least 18"}]}`, recorder.Body.String())
+	})
+
+	t.Run("should return bind errors before validating", func(t *testing.T) {
+
+		var result signup
+
+		err := rest.BindAndValidate(newSignupRequest(`{`), &result)
+
+		var bindErr *rest.BindError
+
+		assert.True(t, errors.As(err, &bindErr))
+	})
+
+	t.Run("should wrap errors of validator", func(t *testing.T) {
+
+		rest.SetValidator(rejectValidator{err: errors.New("rejected")})
+		defer rest.SetValidator(nil)
+
+		var result signup
+
+		err := rest.BindAndValidate(newSignupRequest(`{"email":"eder@mail.com","age":30}`), &result)
+
+		var validationErr *rest.ValidationError
+
+		assert.True(t, errors.As(err, &validationErr))
+		assert.Equal(t, "rejected", err.Error())
+		assert.Equal(t, http.StatusUnprocessableEntity, validationErr.StatusCode())
+	})
+}
@@ -0,0 +1,99 @@
+// Package validator validate structs with github.com/go-playground/validator for rest.BindAndValidate,
+// the fields are named by json tag:
+//
+//	rest.SetValidator(validator.New())
+package validator
+
+import (
+	"errors"
+	"fmt"
+	"github.com/edermanoel94/rest-go"
+	playground "github.com/go-playground/validator/v10"
+	"reflect"
+	"strings"
+)
+
+// Validator adapt a *validator.Validate to rest.StructValidator
+type Validator struct {
+	Validate *playground.Validate
+}
+
+// New create a validator naming fields by json tag
+func New() *Validator {
+
+	validate := playground.New()
+
+	validate.RegisterTagNameFunc(func(field reflect.StructField) string {
+		name := strings.Split(field.Tag.Get("json"), ",")[0]
+		if name == "-" {
+			return ""
+		}
+		if name == "" {
+			return field.Name
+		}
+		return name
+	})
+
+	return &Validator{Validate: validate}
+}
+
+// ValidateStruct validate v, returning a *rest.ValidationError with the fields which failed
+func (v *Validator) ValidateStruct(s interface{}) error {
+
+	err := v.Validate.Struct(s)
+
+	var validationErrs playground.ValidationErrors
+
+	if !errors.As(err, &validationErrs) {
+		return err
+	}
+
+	fields := make([]rest.FieldError, len(validationErrs))
+
+	for i, fieldErr := range validationErrs {
+		fields[i] = rest.FieldError{
+			Name:    fieldName(fieldErr),
+			Rule:    fieldErr.Tag(),
+			Message: Message(fieldErr),
+		}
+		if param := fieldErr.Param(); param != "" {
+			fields[i].Args = []interface{}{param}
+		}
+	}
+
+	return &rest.ValidationError{Fields: fields, Err: err}
+}
+
+// fieldName return the path of field without the struct name, like address.city
+func fieldName(fieldErr playground.FieldError) string {
+	namespace := fieldErr.Namespace()
+	if i := strings.Index(namespace, "."); i >= 0 {
+		return namespace[i+1:]
+	}
+	return namespace
+}
+
+// Message create a english message of the rules most used, like "email is required". rest.ErrorCtx
+// translate it with the message of rest.DefaultCatalog with key validation.<rule>, formatted with the
+// name and param of rule, like "%s deve ter no mínimo %s" for validation.min
+func Message(fieldErr playground.FieldError) string {
+
+	name := fieldName(fieldErr)
+
+	switch fieldErr.Tag() {
+	case "required":
+		return fmt.Sprintf("%s is required", name)
+	case "email":
+		return fmt.Sprintf("%s must be a valid email", name)
+	case "min", "gte":
+		return fmt.Sprintf("%s must be at least %s", name, fieldErr.Param())
+	case "max", "lte":
+		return fmt.Sprintf("%s must be at most %s", name, fieldErr.Param())
+	case "len":
+		return fmt.Sprintf("%s must have length %s", name, fieldErr.Param())
+	case "oneof":
+		return fmt.Sprintf("%s must be one of %s", name, fieldErr.Param())
+	}
+
+	return fmt.Sprintf("%s is not valid on rule %s", name, fieldErr.Tag())
+}
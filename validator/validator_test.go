@@ -0,0 +1,57 @@
+package validator_test
+
+import (
+	"errors"
+	"github.com/edermanoel94/rest-go"
+	"github.com/edermanoel94/rest-go/validator"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+type address struct {
+	City string `json:"city" validate:"required"`
+}
+
+type signup struct {
+	Email   string  `json:"email" validate:"required,email"`
+	Age     int     `json:"age" validate:"min=18"`
+	Plan    string  `validate:"oneof=free pro"`
+	Address address `json:"address"`
+}
+
+func TestValidator(t *testing.T) {
+
+	v := validator.New()
+
+	t.Run("should return nil to a valid struct", func(t *testing.T) {
+
+		err := v.ValidateStruct(&signup{Email: "eder@mail.com", Age: 20, Plan: "pro", Address: address{City: "Recife"}})
+
+		assert.NoError(t, err)
+	})
+
+	t.Run("should return fields named by json tag", func(t *testing.T) {
+
+		err := v.ValidateStruct(&signup{Email: "eder", Age: 10, Plan: "gold"})
+
+		var validationErr *rest.ValidationError
+
+		assert.True(t, errors.As(err, &validationErr))
+		assert.Equal(t, []rest.FieldError{
+			{Name: "email", Rule: "email", Message: "email must be a valid email"},
+			{Name: "age", Rule: "min", Message: "age must be at least 18", Args: []interface{}{"18"}},
+			{Name: "Plan", Rule: "oneof", Message: "Plan must be one of free pro", Args: []interface{}{"free pro"}},
+			{Name: "address.city", Rule: "required", Message: "address.city is required"},
+		}, validationErr.Fields)
+	})
+
+	t.Run("should return error of invalid value", func(t *testing.T) {
+
+		err := v.ValidateStruct(10)
+
+		var validationErr *rest.ValidationError
+
+		assert.Error(t, err)
+		assert.False(t, errors.As(err, &validationErr))
+	})
+}
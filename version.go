@@ -0,0 +1,97 @@
+package rest
+
+import (
+	"context"
+	"net/http"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+// MarshalledVersion marshall and respond json like Marshalled, but strip the fields
+// which don't belong to the version, using tags rest:"since=v2" and rest:"until=v1".
+// A empty version don't strip any field.
+func MarshalledVersion(w http.ResponseWriter, v interface{}, version string, code int) (int, error) {
+
+	s := &shaper{}
+
+	if version != "" {
+		s.keep = append(s.keep, versionFilter(version))
+	}
+
+	return marshalledShaped(context.Background(), w, s, v, code)
+}
+
+// versionFilter keep just the fields available on version
+func versionFilter(version string) func(field reflect.StructField) bool {
+	return func(field reflect.StructField) bool {
+
+		for _, directive := range strings.Split(field.Tag.Get("rest"), ",") {
+
+			key, value := splitDirective(directive)
+
+			switch key {
+			case "since":
+				if compareVersions(version, value) < 0 {
+					return false
+				}
+			case "until":
+				if compareVersions(version, value) > 0 {
+					return false
+				}
+			}
+		}
+
+		return true
+	}
+}
+
+func splitDirective(directive string) (string, string) {
+	if i := strings.Index(directive, "="); i != -1 {
+		return strings.TrimSpace(directive[:i]), strings.TrimSpace(directive[i+1:])
+	}
+	return strings.TrimSpace(directive), ""
+}
+
+// compareVersions compare versions like v1, v2.1 or 3, returning -1, 0 or 1
+func compareVersions(a, b string) int {
+
+	partsA, partsB := versionParts(a), versionParts(b)
+
+	for i := 0; i < len(partsA) || i < len(partsB); i++ {
+
+		var x, y int
+
+		if i < len(partsA) {
+			x = partsA[i]
+		}
+
+		if i < len(partsB) {
+			y = partsB[i]
+		}
+
+		if x < y {
+			return -1
+		}
+
+		if x > y {
+			return 1
+		}
+	}
+
+	return 0
+}
+
+func versionParts(version string) []int {
+
+	version = strings.TrimPrefix(strings.ToLower(strings.TrimSpace(version)), "v")
+
+	parts := make([]int, 0)
+
+	for _, part := range strings.Split(version, ".") {
+		n, _ := strconv.Atoi(part)
+		parts = append(parts, n)
+	}
+
+	return parts
+}
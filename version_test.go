@@ -0,0 +1,87 @@
+package rest_test
+
+import (
+	"github.com/edermanoel94/rest-go"
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+type author struct {
+	Name     string `json:"name"`
+	Nickname string `json:"nickname" rest:"since=v2"`
+}
+
+type article struct {
+	ID       int       `json:"id"`
+	Title    string    `json:"title" rest:"until=v1"`
+	Headline string    `json:"headline" rest:"since=v2"`
+	Draft    bool      `json:"draft,omitempty"`
+	Secret   string    `json:"-"`
+	Author   *author   `json:"author"`
+	Created  time.Time `json:"created"`
+	Tags     []string  `json:"tags"`
+}
+
+func TestMarshalledVersion(t *testing.T) {
+
+	created := time.Date(2020, 1, 2, 3, 4, 5, 0, time.UTC)
+
+	payload := article{
+		ID:       1,
+		Title:    "old",
+		Headline: "new",
+		Secret:   "hidden",
+		Author:   &author{Name: "eder", Nickname: "ed"},
+		Created:  created,
+	}
+
+	testCases := []struct {
+		description string
+		version     string
+		expected    string
+	}{
+		{"should strip fields introduced after v1", "v1",
+			`{"id":1,"title":"old","author":{"name":"eder"},"created":"2020-01-02T03:04:05Z","tags":null}`},
+		{"should strip fields removed after v1", "v2",
+			`{"id":1,"headline":"new","author":{"name":"eder","nickname":"ed"},"created":"2020-01-02T03:04:05Z","tags":null}`},
+		{"should compare minor versions", "v2.1",
+			`{"id":1,"headline":"new","author":{"name":"eder","nickname":"ed"},"created":"2020-01-02T03:04:05Z","tags":null}`},
+		{"should not strip without version", "",
+			`{"id":1,"title":"old","headline":"new","author":{"name":"eder","nickname":"ed"},"created":"2020-01-02T03:04:05Z","tags":null}`},
+	}
+
+	for _, tc := range testCases {
+
+		t.Run(tc.description, func(t *testing.T) {
+
+			recorder := httptest.NewRecorder()
+
+			rest.MarshalledVersion(recorder, &payload, tc.version, http.StatusOK)
+
+			assert.Equal(t, http.StatusOK, recorder.Code)
+			assert.Equal(t, tc.expected, recorder.Body.String())
+		})
+	}
+
+	t.Run("should promote fields of embedded structs", func(t *testing.T) {
+
+		type base struct {
+			ID      int    `json:"id"`
+			Version string `json:"version" rest:"since=v3"`
+		}
+
+		payload := struct {
+			base
+			Name string `json:"name"`
+		}{base{ID: 7, Version: "x"}, "cale"}
+
+		recorder := httptest.NewRecorder()
+
+		rest.MarshalledVersion(recorder, payload, "v2", http.StatusOK)
+
+		assert.Equal(t, `{"id":7,"name":"cale"}`, recorder.Body.String())
+	})
+}
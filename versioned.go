@@ -0,0 +1,155 @@
+package rest
+
+import (
+	"fmt"
+	"net/http"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// VersionHeader is the header read by Versioned when Accept don't ask a version, like API-Version: 2
+const VersionHeader = "API-Version"
+
+const versionParam = "version"
+
+// vendorMediaType match vendor media types with version, like application/vnd.myapp.v2+json
+var vendorMediaType = regexp.MustCompile(`^application/vnd\.[a-z0-9][a-z0-9.-]*\.v(\d+)\+json$`)
+
+// Versioner choose the representation of a resource by the version asked by client, see Versioned
+type Versioner struct {
+	w        http.ResponseWriter
+	r        *http.Request
+	opts     []Option
+	payloads map[int]interface{}
+	fallback int
+}
+
+// Versioned create a dispatcher which respond the payload of version asked by client, like
+// rest.Versioned(w, r).V1(v1).V2(v2).Respond(http.StatusOK). The version is read from a vendor
+// media type on Accept, like application/vnd.myapp.v2+json, then from API-Version header and
+// from query parameter version, like ?version=2. The latest version is sent when none is asked.
+func Versioned(w http.ResponseWriter, r *http.Request, opts ...Option) *Versioner {
+	return &Versioner{w: w, r: r, opts: opts, payloads: make(map[int]interface{})}
+}
+
+// Version add the payload of a version
+func (v *Versioner) Version(version int, payload interface{}) *Versioner {
+	v.payloads[version] = payload
+	return v
+}
+
+// V1 add the payload of version 1
+func (v *Versioner) V1(payload interface{}) *Versioner {
+	return v.Version(1, payload)
+}
+
+// V2 add the payload of version 2
+func (v *Versioner) V2(payload interface{}) *Versioner {
+	return v.Version(2, payload)
+}
+
+// V3 add the payload of version 3
+func (v *Versioner) V3(payload interface{}) *Versioner {
+	return v.Version(3, payload)
+}
+
+// Default change the version sent when the client don't ask one, instead of the latest
+func (v *Versioner) Default(version int) *Versioner {
+	v.fallback = version
+	return v
+}
+
+// Respond marshall the payload of version asked and respond json with code, the Content-Type is the
+// vendor media type asked on Accept, or application/json. A version without payload respond 406.
+func (v *Versioner) Respond(code int) (int, error) {
+
+	v.w.Header().Add("Vary", accept)
+	v.w.Header().Add("Vary", VersionHeader)
+
+	version, mediaType, asked := v.asked()
+
+	if !asked {
+		version = v.defaultVersion()
+	}
+
+	payload, ok := v.payloads[version]
+
+	if !ok {
+		err := fmt.Errorf("%w, version v%d is not supported, supported versions: %s", ErrNotAcceptable, version, v.supported())
+		return newCall(v.r.Context(), v.opts...).fail(v.w, err, http.StatusNotAcceptable)
+	}
+
+	opts := v.opts
+
+	if mediaType != "" {
+		opts = append([]Option{WithContentType(mediaType)}, opts...)
+	}
+
+	return newCall(v.r.Context(), opts...).marshalled(v.w, payload, code)
+}
+
+// asked return the version asked by client, with the vendor media type when it was asked on Accept
+func (v *Versioner) asked() (int, string, bool) {
+
+	for _, accepted := range parseAccept(v.r.Header.Get(accept)) {
+
+		if accepted.quality <= 0 {
+			continue
+		}
+
+		if match := vendorMediaType.FindStringSubmatch(accepted.mediaType); match != nil {
+			version, _ := strconv.Atoi(match[1])
+			return version, accepted.mediaType, true
+		}
+	}
+
+	for _, value := range []string{v.r.Header.Get(VersionHeader), v.r.URL.Query().Get(versionParam)} {
+
+		value = strings.TrimPrefix(strings.ToLower(strings.TrimSpace(value)), "v")
+
+		if version, err := strconv.Atoi(value); err == nil {
+			return version, "", true
+		}
+	}
+
+	return 0, "", false
+}
+
+func (v *Versioner) defaultVersion() int {
+
+	if v.fallback != 0 {
+		return v.fallback
+	}
+
+	latest := 0
+
+	for version := range v.payloads {
+		if version > latest {
+			latest = version
+		}
+	}
+
+	return latest
+}
+
+// supported return the versions with payload, like v1, v2
+func (v *Versioner) supported() string {
+
+	versions := make([]int, 0, len(v.payloads))
+
+	for version := range v.payloads {
+		versions = append(versions, version)
+	}
+
+	sort.Ints(versions)
+
+	names := make([]string, len(versions))
+
+	for i, version := range versions {
+		names[i] = "v" + strconv.Itoa(version)
+	}
+
+	return strings.Join(names, ", ")
+}
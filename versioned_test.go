@@ -0,0 +1,72 @@
+package rest_test
+
+import (
+	"github.com/edermanoel94/rest-go"
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestVersioned(t *testing.T) {
+
+	testCases := []struct {
+		description string
+		target      string
+		accept      string
+		version     string
+		statusCode  int
+		contentType string
+		body        string
+	}{
+		{"should send version of vendor media type", "/products/1", "application/vnd.shop.v1+json", "",
+			http.StatusOK, "application/vnd.shop.v1+json", `{"title":"tv"}`},
+		{"should send version of most preferred media type", "/products/1", "application/vnd.shop.v1+json;q=0.5, application/vnd.shop.v2+json", "",
+			http.StatusOK, "application/vnd.shop.v2+json", `{"headline":"tv"}`},
+		{"should send version of header", "/products/1", "application/json", "1",
+			http.StatusOK, "application/json", `{"title":"tv"}`},
+		{"should send version of query parameter", "/products/1?version=v1", "", "",
+			http.StatusOK, "application/json", `{"title":"tv"}`},
+		{"should send latest version when none is asked", "/products/1", "*/*", "",
+			http.StatusOK, "application/json", `{"headline":"tv"}`},
+		{"should not accept a version without payload", "/products/1", "application/vnd.shop.v3+json", "",
+			http.StatusNotAcceptable, "application/json", `{"message":"not acceptable, version v3 is not supported, supported versions: v1, v2"}`},
+	}
+
+	for _, tc := range testCases {
+
+		t.Run(tc.description, func(t *testing.T) {
+
+			request := httptest.NewRequest(http.MethodGet, tc.target, nil)
+			request.Header.Set("Accept", tc.accept)
+			request.Header.Set("API-Version", tc.version)
+
+			recorder := httptest.NewRecorder()
+
+			rest.Versioned(recorder, request).
+				V1(map[string]string{"title": "tv"}).
+				V2(map[string]string{"headline": "tv"}).
+				Respond(http.StatusOK)
+
+			assert.Equal(t, tc.statusCode, recorder.Code)
+			assert.Equal(t, tc.contentType, recorder.Header().Get("Content-Type"))
+			assert.Equal(t, []string{"Accept", "API-Version"}, recorder.Header()["Vary"])
+			assert.Equal(t, tc.body, recorder.Body.String())
+		})
+	}
+
+	t.Run("should send default version when none is asked", func(t *testing.T) {
+
+		recorder := httptest.NewRecorder()
+
+		rest.Versioned(recorder, httptest.NewRequest(http.MethodGet, "/products/1", nil), rest.MaxAge(0)).
+			Version(1, []int{1}).
+			Version(4, []int{4}).
+			Default(1).
+			Respond(http.StatusOK)
+
+		assert.Equal(t, http.StatusOK, recorder.Code)
+		assert.Equal(t, "[1]", recorder.Body.String())
+		assert.Equal(t, "max-age=0", recorder.Header().Get("Cache-Control"))
+	})
+}
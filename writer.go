@@ -0,0 +1,174 @@
+package rest
+
+import (
+	"bufio"
+	"io"
+	"net"
+	"net/http"
+)
+
+// responseWriter is the base of every wrapper of http.ResponseWriter on the package,
+// it keeps track of the status and bytes sent and forward the optional interfaces
+// (http.Flusher, http.Hijacker, http.Pusher and io.ReaderFrom) to the wrapped writer,
+// so streaming, websockets and sendfile keep working when middlewares are stacked.
+// Handlers receive it through expose, which hide the interfaces the wrapped writer don't have.
+type responseWriter struct {
+	http.ResponseWriter
+	status      int
+	written     int64
+	wroteHeader bool
+	// err is the first error of Write or ReadFrom
+	err error
+}
+
+func newResponseWriter(w http.ResponseWriter) *responseWriter {
+	return &responseWriter{ResponseWriter: w, status: http.StatusOK}
+}
+
+func (rw *responseWriter) WriteHeader(code int) {
+	if rw.wroteHeader {
+		return
+	}
+	rw.status = code
+	rw.wroteHeader = true
+	rw.ResponseWriter.WriteHeader(code)
+}
+
+func (rw *responseWriter) Write(b []byte) (int, error) {
+	if !rw.wroteHeader {
+		rw.WriteHeader(http.StatusOK)
+	}
+	n, err := rw.ResponseWriter.Write(b)
+	rw.written += int64(n)
+	rw.record(err)
+	return n, err
+}
+
+func (rw *responseWriter) record(err error) {
+	if rw.err == nil {
+		rw.err = err
+	}
+}
+
+// Flush send buffered data to the client, when the wrapped writer can't flush it does nothing
+func (rw *responseWriter) Flush() {
+	if flusher, ok := rw.ResponseWriter.(http.Flusher); ok {
+		if !rw.wroteHeader {
+			rw.WriteHeader(http.StatusOK)
+		}
+		flusher.Flush()
+	}
+}
+
+// Hijack let the caller take over the connection, used by websockets
+func (rw *responseWriter) Hijack() (net.Conn, *bufio.ReadWriter, error) {
+	if hijacker, ok := rw.ResponseWriter.(http.Hijacker); ok {
+		return hijacker.Hijack()
+	}
+	return nil, nil, http.ErrNotSupported
+}
+
+// Push initiate a HTTP/2 server push
+func (rw *responseWriter) Push(target string, opts *http.PushOptions) error {
+	if pusher, ok := rw.ResponseWriter.(http.Pusher); ok {
+		return pusher.Push(target, opts)
+	}
+	return http.ErrNotSupported
+}
+
+// ReadFrom use the io.ReaderFrom of the wrapped writer when exists, so sendfile can be used
+func (rw *responseWriter) ReadFrom(r io.Reader) (int64, error) {
+	if readerFrom, ok := rw.ResponseWriter.(io.ReaderFrom); ok {
+		if !rw.wroteHeader {
+			rw.WriteHeader(http.StatusOK)
+		}
+		n, err := readerFrom.ReadFrom(r)
+		rw.written += n
+		rw.record(err)
+		return n, err
+	}
+	return io.Copy(writerOnly{rw}, r)
+}
+
+// Unwrap return the wrapped writer, used by http.ResponseController
+func (rw *responseWriter) Unwrap() http.ResponseWriter {
+	return rw.ResponseWriter
+}
+
+// wrapper is a http.ResponseWriter wrapping other, with every optional interface
+type wrapper interface {
+	http.ResponseWriter
+	http.Flusher
+	http.Hijacker
+	http.Pusher
+	io.ReaderFrom
+	Unwrap() http.ResponseWriter
+}
+
+// baseWriter are the methods of wrapper which work with every writer, ReadFrom fall back to io.Copy
+type baseWriter interface {
+	http.ResponseWriter
+	io.ReaderFrom
+	Unwrap() http.ResponseWriter
+}
+
+// expose return w with just the http.Flusher, http.Hijacker and http.Pusher which underlying
+// implements, so checks like w.(http.Flusher) report what underlying can do
+func expose(w wrapper, underlying http.ResponseWriter) http.ResponseWriter {
+
+	_, flusher := underlying.(http.Flusher)
+	_, hijacker := underlying.(http.Hijacker)
+	_, pusher := underlying.(http.Pusher)
+
+	switch {
+	case flusher && hijacker && pusher:
+		return struct {
+			baseWriter
+			http.Flusher
+			http.Hijacker
+			http.Pusher
+		}{w, w, w, w}
+	case flusher && hijacker:
+		return struct {
+			baseWriter
+			http.Flusher
+			http.Hijacker
+		}{w, w, w}
+	case flusher && pusher:
+		return struct {
+			baseWriter
+			http.Flusher
+			http.Pusher
+		}{w, w, w}
+	case hijacker && pusher:
+		return struct {
+			baseWriter
+			http.Hijacker
+			http.Pusher
+		}{w, w, w}
+	case flusher:
+		return struct {
+			baseWriter
+			http.Flusher
+		}{w, w}
+	case hijacker:
+		return struct {
+			baseWriter
+			http.Hijacker
+		}{w, w}
+	case pusher:
+		return struct {
+			baseWriter
+			http.Pusher
+		}{w, w}
+	default:
+		return struct {
+			baseWriter
+		}{w}
+	}
+}
+
+// writerOnly hide every method except Write, avoiding io.Copy call ReadFrom again
+type writerOnly struct {
+	io.Writer
+}
@@ -0,0 +1,124 @@
+package rest
+
+import (
+	"bufio"
+	"github.com/stretchr/testify/assert"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+type fullWriter struct {
+	*httptest.ResponseRecorder
+	hijacked bool
+	pushed   string
+	readFrom bool
+}
+
+func (f *fullWriter) Hijack() (net.Conn, *bufio.ReadWriter, error) {
+	f.hijacked = true
+	return nil, nil, nil
+}
+
+func (f *fullWriter) Push(target string, _ *http.PushOptions) error {
+	f.pushed = target
+	return nil
+}
+
+func (f *fullWriter) ReadFrom(r io.Reader) (int64, error) {
+	f.readFrom = true
+	return io.Copy(f.ResponseRecorder, r)
+}
+
+func TestResponseWriter(t *testing.T) {
+
+	t.Run("should forward optional interfaces to wrapped writer", func(t *testing.T) {
+
+		underlying := &fullWriter{ResponseRecorder: httptest.NewRecorder()}
+
+		var w http.ResponseWriter = newResponseWriter(underlying)
+
+		_, _, err := w.(http.Hijacker).Hijack()
+
+		assert.NoError(t, err)
+		assert.True(t, underlying.hijacked)
+
+		assert.NoError(t, w.(http.Pusher).Push("/style.css", nil))
+		assert.Equal(t, "/style.css", underlying.pushed)
+
+		n, err := w.(io.ReaderFrom).ReadFrom(strings.NewReader("cale"))
+
+		assert.NoError(t, err)
+		assert.Equal(t, int64(4), n)
+		assert.True(t, underlying.readFrom)
+
+		w.(http.Flusher).Flush()
+
+		assert.True(t, underlying.Flushed)
+		assert.Equal(t, int64(4), w.(*responseWriter).written)
+	})
+
+	t.Run("should degrade when wrapped writer don't support interfaces", func(t *testing.T) {
+
+		recorder := httptest.NewRecorder()
+
+		rw := newResponseWriter(struct{ http.ResponseWriter }{recorder})
+
+		_, _, err := rw.Hijack()
+
+		assert.Equal(t, http.ErrNotSupported, err)
+		assert.Equal(t, http.ErrNotSupported, rw.Push("/style.css", nil))
+
+		n, err := rw.ReadFrom(strings.NewReader("cale"))
+
+		assert.NoError(t, err)
+		assert.Equal(t, int64(4), n)
+		assert.Equal(t, "cale", recorder.Body.String())
+	})
+
+	t.Run("should expose just the interfaces of wrapped writer", func(t *testing.T) {
+
+		plain := struct{ http.ResponseWriter }{httptest.NewRecorder()}
+
+		w := expose(newResponseWriter(plain), plain)
+
+		_, flusher := w.(http.Flusher)
+		_, hijacker := w.(http.Hijacker)
+		_, pusher := w.(http.Pusher)
+		_, readerFrom := w.(io.ReaderFrom)
+
+		assert.False(t, flusher)
+		assert.False(t, hijacker)
+		assert.False(t, pusher)
+		assert.True(t, readerFrom)
+
+		full := &fullWriter{ResponseRecorder: httptest.NewRecorder()}
+
+		w = expose(newResponseWriter(full), full)
+
+		_, flusher = w.(http.Flusher)
+		_, hijacker = w.(http.Hijacker)
+		_, pusher = w.(http.Pusher)
+
+		assert.True(t, flusher)
+		assert.True(t, hijacker)
+		assert.True(t, pusher)
+	})
+
+	t.Run("should keep the first status written", func(t *testing.T) {
+
+		recorder := httptest.NewRecorder()
+
+		rw := newResponseWriter(recorder)
+
+		rw.WriteHeader(http.StatusCreated)
+		rw.WriteHeader(http.StatusInternalServerError)
+
+		assert.Equal(t, http.StatusCreated, rw.status)
+		assert.Equal(t, http.StatusCreated, recorder.Code)
+		assert.Equal(t, recorder, rw.Unwrap())
+	})
+}
@@ -0,0 +1,20 @@
+package rest
+
+import (
+	"context"
+	"net/http"
+)
+
+// XML marshall v with encoding/xml and respond application/xml, errors are sent on xml too
+func XML(w http.ResponseWriter, v interface{}, code int, opts ...Option) (int, error) {
+	return Encoded(w, applicationXml, v, code, opts...)
+}
+
+// XMLError send a error like Error, but as <error><message>...</message></error>
+func XMLError(w http.ResponseWriter, err error, code int, opts ...Option) (int, error) {
+
+	c := newCall(context.Background(), opts...)
+	c.errorType = applicationXml
+
+	return c.fail(w, err, code)
+}
@@ -0,0 +1,66 @@
+package rest_test
+
+import (
+	"encoding/xml"
+	"errors"
+	"github.com/edermanoel94/rest-go"
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+type xmlProduct struct {
+	XMLName xml.Name `xml:"product"`
+	ID      int      `xml:"id,attr"`
+	Name    string   `xml:"name"`
+}
+
+func TestXML(t *testing.T) {
+
+	t.Run("should marshal to xml", func(t *testing.T) {
+
+		recorder := httptest.NewRecorder()
+
+		rest.XML(recorder, xmlProduct{ID: 1, Name: "tv"}, http.StatusOK)
+
+		assert.Equal(t, http.StatusOK, recorder.Code)
+		assert.Equal(t, "application/xml", recorder.Header().Get("Content-Type"))
+		assert.Equal(t, `<product id="1"><name>tv</name></product>`, recorder.Body.String())
+	})
+
+	t.Run("should send a xml error when cannot marshal", func(t *testing.T) {
+
+		recorder := httptest.NewRecorder()
+
+		rest.XML(recorder, map[string]string{"name": "tv"}, http.StatusOK)
+
+		assert.Equal(t, http.StatusInternalServerError, recorder.Code)
+		assert.Equal(t, "application/xml", recorder.Header().Get("Content-Type"))
+		assert.Equal(t, "<error><message>xml: unsupported type: map[string]string</message></error>", recorder.Body.String())
+	})
+}
+
+func TestXMLError(t *testing.T) {
+
+	t.Run("should send error as xml", func(t *testing.T) {
+
+		recorder := httptest.NewRecorder()
+
+		rest.XMLError(recorder, errors.New(`name "tv" & <price> are invalid`), http.StatusBadRequest)
+
+		assert.Equal(t, http.StatusBadRequest, recorder.Code)
+		assert.Equal(t, "application/xml", recorder.Header().Get("Content-Type"))
+		assert.Equal(t, "<error><message>name &#34;tv&#34; &amp; &lt;price&gt; are invalid</message></error>", recorder.Body.String())
+	})
+
+	t.Run("should send status of StatusCoder", func(t *testing.T) {
+
+		recorder := httptest.NewRecorder()
+
+		rest.XMLError(recorder, unavailableError{}, http.StatusBadRequest)
+
+		assert.Equal(t, http.StatusServiceUnavailable, recorder.Code)
+		assert.Equal(t, "120", recorder.Header().Get("Retry-After"))
+	})
+}